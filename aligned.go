@@ -0,0 +1,192 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"math/bits"
+)
+
+// findAlignedExclusion tries the combinations of values for size cells
+// that all see some cell having at most size candidates. The cells do not
+// need to share a house, cells that do not see each other may have the
+// same value. A combination is excluded if it leaves some other cell
+// without candidates, that is the cell sees cells of the combination
+// having all its candidates, for example a bivalue cell seeing both cells
+// of a pair. Candidates not in any remaining combination are eliminated.
+func (s *Sudoku) findAlignedExclusion(size int) finderResult {
+	masks := candidateMasks(s.Candidates)
+
+	cells := []int{}
+	excluders := cellSet{}
+	for idx, mask := range masks {
+		count := bits.OnesCount16(mask)
+		if count >= 2 {
+			cells = append(cells, idx)
+		}
+		if count > 0 && count <= size {
+			excluders.add(idx)
+		}
+	}
+
+	res := finderResult{Solved: nil, Eliminated: nil}
+	tuple := make([]int, 0, size)
+
+	// Extend the tuple with cells keeping some excluder seen by all
+	var search func(start int, common cellSet) bool
+	search = func(start int, common cellSet) bool {
+		if len(tuple) == size {
+			res = s.alignedExclusion(&masks, tuple)
+			return len(res.Eliminated) > 0
+		}
+
+		for i := start; i < len(cells); i++ {
+			c := common.and(peerSets[cells[i]])
+			if c.empty() {
+				continue
+			}
+
+			tuple = append(tuple, cells[i])
+			if search(i+1, c) {
+				return true
+			}
+			tuple = tuple[:len(tuple)-1]
+		}
+
+		return false
+	}
+
+	if search(0, excluders) {
+		return res
+	}
+
+	return finderResult{Solved: nil, Eliminated: nil}
+}
+
+func (s *Sudoku) alignedExclusion(masks *[sudokuGridSize]uint16, tuple []int) finderResult {
+	none := finderResult{Solved: nil, Eliminated: nil}
+
+	inTuple := cellSet{}
+	for _, idx := range tuple {
+		inTuple.add(idx)
+	}
+
+	// Cells that can be emptied by the tuple
+	others := []int{}
+	for idx, mask := range masks {
+		if mask == 0 || inTuple.has(idx) || bits.OnesCount16(mask) > len(tuple) {
+			continue
+		}
+
+		for _, t := range tuple {
+			if peerSets[idx].has(t) {
+				others = append(others, idx)
+				break
+			}
+		}
+	}
+
+	if len(others) == 0 {
+		return none
+	}
+
+	digits := make([][]int8, len(tuple))
+	for i, idx := range tuple {
+		digits[i] = maskDigits(masks[idx])
+	}
+
+	survived := make([]uint16, len(tuple))
+	used := cellSet{}
+	values := make([]int8, len(tuple))
+	choice := make([]int, len(tuple))
+
+	for {
+		fits := true
+		for i, t := range tuple {
+			values[i] = digits[i][choice[i]]
+			for j := 0; j < i; j++ {
+				if values[j] == values[i] && peerSets[t].has(tuple[j]) {
+					fits = false
+				}
+			}
+		}
+
+		if fits {
+			excluded := false
+			for _, idx := range others {
+				taken := uint16(0)
+				for i, t := range tuple {
+					if peerSets[idx].has(t) {
+						taken |= 1 << uint(values[i])
+					}
+				}
+
+				if masks[idx]&^taken == 0 {
+					excluded = true
+					used.add(idx)
+					break
+				}
+			}
+
+			if !excluded {
+				for i, v := range values {
+					survived[i] |= 1 << uint(v)
+				}
+			}
+		}
+
+		// Next combination of values
+		i := 0
+		for ; i < len(choice); i++ {
+			choice[i]++
+			if choice[i] < len(digits[i]) {
+				break
+			}
+			choice[i] = 0
+		}
+
+		if i == len(choice) {
+			break
+		}
+	}
+
+	found := CellList{}
+	for i, idx := range tuple {
+		found = append(found, s.maskCandidates(idx, masks[idx]&^survived[i])...)
+	}
+
+	if len(found) == 0 {
+		return none
+	}
+
+	pattern := CellList{}
+	for _, idx := range tuple {
+		pattern = append(pattern, s.maskCandidates(idx, masks[idx])...)
+	}
+	for _, idx := range used.indexes() {
+		pattern = append(pattern, s.maskCandidates(idx, masks[idx])...)
+	}
+
+	return finderResult{Solved: nil, Eliminated: found, Pattern: uniqueCells(pattern)}
+}
+
+func (s *Sudoku) findAlignedPairExclusions() finderResult {
+	return s.findAlignedExclusion(2)
+}
+
+func (s *Sudoku) findAlignedTripleExclusions() finderResult {
+	return s.findAlignedExclusion(3)
+}
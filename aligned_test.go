@@ -0,0 +1,64 @@
+package sudoku_test
+
+import (
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+func TestAlignedExclusion(t *testing.T) {
+	tests := []struct {
+		grid       string
+		name       string
+		eliminated sudoku.CellList
+		pattern    sudoku.CellList
+	}{
+		// r9c4 (3457) and r9c5 (3478): with 3 in r9c4, r9c5 being 4
+		// would empty r9c7 (34), being 7 r8c5 (37) and being 8 r9c2 (38)
+		{"020800760150000000006020100000000507308201009000003000000000000400600800600009010",
+			"aligned pair exclusion",
+			sudoku.CellList{cell(9, 4, 8, 3)},
+			sudoku.CellList{cell(8, 5, 8, 3), cell(8, 5, 8, 7), cell(9, 2, 7, 3), cell(9, 2, 7, 8),
+				cell(9, 4, 8, 3), cell(9, 4, 8, 4), cell(9, 4, 8, 5), cell(9, 4, 8, 7),
+				cell(9, 5, 8, 3), cell(9, 5, 8, 4), cell(9, 5, 8, 7), cell(9, 5, 8, 8),
+				cell(9, 7, 9, 3), cell(9, 7, 9, 4)}},
+		// Needs three cells, no pair is enough
+		{"000000708700300000925840000004930005200050000308000020000106004400000300009000860",
+			"aligned triple exclusion",
+			sudoku.CellList{cell(1, 6, 2, 9)},
+			nil},
+		// r1c9 (245), r3c7 (48) and r9c8 (12) are not in one house, but
+		// all see r2c8 (28) and r3c8 (148). With 4 in r1c9, r3c7 is 8
+		// and r9c8 being 1 would empty r3c8, being 2 r2c8
+		{"000000060100000709030672000370025100001300050900001000000060003000000000004807906",
+			"aligned triple exclusion",
+			sudoku.CellList{cell(1, 9, 3, 4)},
+			sudoku.CellList{cell(1, 9, 3, 2), cell(1, 9, 3, 4), cell(1, 9, 3, 5),
+				cell(2, 8, 3, 2), cell(2, 8, 3, 8), cell(3, 7, 3, 4), cell(3, 7, 3, 8),
+				cell(3, 8, 3, 1), cell(3, 8, 3, 4), cell(3, 8, 3, 8),
+				cell(9, 8, 9, 1), cell(9, 8, 9, 2)}},
+	}
+
+	_, ok, err := forcingState(t, tests[1].grid).HintWith([]string{"aligned pair exclusion"})
+	assert.NilError(t, err)
+	assert.Assert(t, !ok)
+
+	for _, test := range tests {
+		s := forcingState(t, test.grid)
+
+		solution, ok := s.Solution()
+		assert.Assert(t, ok)
+
+		step, ok, err := s.HintWith([]string{test.name})
+		assert.NilError(t, err)
+		assert.Assert(t, ok)
+		assert.DeepEqual(t, test.eliminated, step.Eliminated)
+		if test.pattern != nil {
+			assert.DeepEqual(t, test.pattern, step.Pattern)
+		}
+
+		assert.NilError(t, s.ApplyStep(step))
+		assertSound(t, s, solution)
+	}
+}
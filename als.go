@@ -0,0 +1,407 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"math/bits"
+)
+
+// cellSet is a set of cells by their index in the grid.
+type cellSet [2]uint64
+
+func (cs *cellSet) add(idx int) {
+	cs[idx/64] |= 1 << uint(idx%64)
+}
+
+func (cs cellSet) has(idx int) bool {
+	return cs[idx/64]&(1<<uint(idx%64)) != 0
+}
+
+func (cs cellSet) and(other cellSet) cellSet {
+	return cellSet{cs[0] & other[0], cs[1] & other[1]}
+}
+
+func (cs cellSet) or(other cellSet) cellSet {
+	return cellSet{cs[0] | other[0], cs[1] | other[1]}
+}
+
+func (cs cellSet) andNot(other cellSet) cellSet {
+	return cellSet{cs[0] &^ other[0], cs[1] &^ other[1]}
+}
+
+func (cs cellSet) empty() bool {
+	return cs[0] == 0 && cs[1] == 0
+}
+
+// subsetOf tells if all cells of cs are in other.
+func (cs cellSet) subsetOf(other cellSet) bool {
+	return cs.andNot(other).empty()
+}
+
+func (cs cellSet) indexes() []int {
+	res := []int{}
+
+	for i, word := range cs {
+		for word != 0 {
+			bit := bits.TrailingZeros64(word)
+			res = append(res, i*64+bit)
+			word &^= 1 << uint(bit)
+		}
+	}
+
+	return res
+}
+
+func indexPos(idx int) Pos {
+	return Pos{}.init(int8(idx/sudokuNumbers+1), int8(idx%sudokuNumbers+1))
+}
+
+var peerSets = func() [sudokuGridSize]cellSet {
+	var res [sudokuGridSize]cellSet
+
+	for i := 0; i < sudokuGridSize; i++ {
+		pi := indexPos(i)
+
+		for j := 0; j < sudokuGridSize; j++ {
+			if i != j && pi.sees(indexPos(j)) {
+				res[i].add(j)
+			}
+		}
+	}
+
+	return res
+}()
+
+// seenByAll returns the cells that see every cell of cs.
+func seenByAll(cs cellSet) cellSet {
+	res := cellSet{^uint64(0), ^uint64(0)}
+
+	for _, idx := range cs.indexes() {
+		res = res.and(peerSets[idx])
+	}
+
+	return res
+}
+
+// An almost locked set is N cells in a house with N+1 candidates between
+// them.
+type als struct {
+	cells cellSet
+	mask  uint16
+	// Cells having the number
+	digits [sudokuNumbers + 1]cellSet
+	// Cells outside the set that see all cells having the number
+	seen [sudokuNumbers + 1]cellSet
+}
+
+func (a *als) hasDigit(n int8) bool {
+	return a.mask&(1<<uint(n)) != 0
+}
+
+// alsSet holds the almost locked sets of a grid state.
+type alsSet struct {
+	masks [sudokuGridSize]uint16
+	sets  []*als
+}
+
+func (s *Sudoku) findALSs() *alsSet {
+	res := &alsSet{masks: candidateMasks(s.Candidates)}
+	seen := map[cellSet]bool{}
+
+	funs := []cellGetter{s.getCandidateRow, s.getCandidateColumn, s.getCandidateBox}
+
+	for _, fun := range funs {
+		for i := int8(1); i <= sudokuNumbers; i++ {
+			poss := ucpos(fun(i))
+
+			for size := 1; size < len(poss); size++ {
+				combs := newCombination(len(poss), size)
+
+				for {
+					var idxs intList = combs.next()
+					if idxs == nil {
+						break
+					}
+
+					var cells cellSet
+					var mask uint16
+
+					for _, idx := range idxs {
+						pidx := poss[idx].index()
+						cells.add(pidx)
+						mask |= res.masks[pidx]
+					}
+
+					if bits.OnesCount16(mask) != size+1 || seen[cells] {
+						continue
+					}
+					seen[cells] = true
+
+					res.sets = append(res.sets, res.newALS(cells, mask))
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+func (as *alsSet) newALS(cells cellSet, mask uint16) *als {
+	a := &als{cells: cells, mask: mask}
+
+	for _, idx := range cells.indexes() {
+		for n := 1; n <= sudokuNumbers; n++ {
+			if as.masks[idx]&(1<<uint(n)) != 0 {
+				a.digits[n].add(idx)
+			}
+		}
+	}
+
+	for n := 1; n <= sudokuNumbers; n++ {
+		if !a.digits[n].empty() {
+			a.seen[n] = seenByAll(a.digits[n])
+		}
+	}
+
+	return a
+}
+
+// rccs returns the restricted common candidates of two disjoint sets as
+// a mask: numbers in both, where all the cells having the number see each
+// other, so that at most one of the sets can have it.
+func rccs(a, b *als) uint16 {
+	if !a.cells.and(b.cells).empty() {
+		return 0
+	}
+
+	var res uint16
+	common := a.mask & b.mask
+
+	for n := 1; n <= sudokuNumbers; n++ {
+		if common&(1<<uint(n)) != 0 && b.digits[n].subsetOf(a.seen[n]) {
+			res |= 1 << uint(n)
+		}
+	}
+
+	return res
+}
+
+// eliminate returns the candidates n in target cells.
+func (as *alsSet) eliminate(target cellSet, n int) CellList {
+	found := CellList{}
+
+	for _, idx := range target.indexes() {
+		if as.masks[idx]&(1<<uint(n)) != 0 {
+			found = append(found, Cell{Pos: indexPos(idx), Value: int8(n)})
+		}
+	}
+
+	return found
+}
+
+// pattern returns the candidates of the cells.
+func (as *alsSet) pattern(cells cellSet) CellList {
+	res := CellList{}
+
+	for _, idx := range cells.indexes() {
+		for n := 1; n <= sudokuNumbers; n++ {
+			if as.masks[idx]&(1<<uint(n)) != 0 {
+				res = append(res, Cell{Pos: indexPos(idx), Value: int8(n)})
+			}
+		}
+	}
+
+	return res
+}
+
+// lockedElims eliminates the numbers of mask from the cells that see all
+// the cells having the number in every set.
+func (as *alsSet) lockedElims(mask uint16, sets ...*als) CellList {
+	found := CellList{}
+
+	for n := 1; n <= sudokuNumbers; n++ {
+		if mask&(1<<uint(n)) == 0 {
+			continue
+		}
+
+		target := cellSet{^uint64(0), ^uint64(0)}
+		for _, a := range sets {
+			if a.hasDigit(int8(n)) {
+				target = target.and(a.seen[n])
+			}
+		}
+
+		found = append(found, as.eliminate(target, n)...)
+	}
+
+	return found
+}
+
+// ALS-XZ: sets A and B with a restricted common candidate x. One of them
+// does not have x and is locked, so any other common number z is in A or
+// B and is eliminated from cells seeing all z of both. If the sets are
+// doubly linked, both of them are locked without the linking numbers.
+func (s *Sudoku) findALSXZ() finderResult {
+	d := deductions{}
+
+	as := s.findALSs()
+
+	for i, a := range as.sets {
+		for _, b := range as.sets[i+1:] {
+			rcc := rccs(a, b)
+			if rcc == 0 {
+				continue
+			}
+
+			var nfound CellList
+			if bits.OnesCount16(rcc) == 1 {
+				nfound = as.lockedElims(a.mask&b.mask&^rcc, a, b)
+			} else {
+				nfound = as.lockedElims(rcc, a, b)
+				nfound = append(nfound, as.lockedElims(a.mask&^rcc, a)...)
+				nfound = append(nfound, as.lockedElims(b.mask&^rcc, b)...)
+			}
+
+			d.add(finderResult{Solved: nil, Eliminated: nfound, Pattern: as.pattern(a.cells.or(b.cells))})
+		}
+	}
+
+	return d.result()
+}
+
+// ALS-XY-Wing: sets A and B both linked to C, with different restricted
+// common candidates x and y. A or B is locked, so a number z common to A
+// and B is eliminated from cells seeing all z of both.
+func (s *Sudoku) findALSXYWings() finderResult {
+	d := deductions{}
+
+	as := s.findALSs()
+
+	type link struct {
+		other int
+		rcc   uint16
+	}
+
+	links := make([][]link, len(as.sets))
+	for i, a := range as.sets {
+		for j := i + 1; j < len(as.sets); j++ {
+			if rcc := rccs(a, as.sets[j]); rcc != 0 {
+				links[i] = append(links[i], link{j, rcc})
+				links[j] = append(links[j], link{i, rcc})
+			}
+		}
+	}
+
+	for ci, c := range as.sets {
+		for k, la := range links[ci] {
+			for _, lb := range links[ci][k+1:] {
+				a, b := as.sets[la.other], as.sets[lb.other]
+
+				if !a.cells.and(b.cells).empty() {
+					continue
+				}
+
+				// Need x != y, so not both links by the same single number
+				if la.rcc == lb.rcc && bits.OnesCount16(la.rcc) == 1 {
+					continue
+				}
+
+				zs := a.mask & b.mask &^ (la.rcc | lb.rcc)
+				if zs == 0 {
+					continue
+				}
+
+				nfound := as.lockedElims(zs, a, b)
+				nfound = CellList(nfound).Filter(func(cell Cell) bool {
+					return !c.cells.has(cell.Pos.index())
+				})
+
+				d.add(finderResult{Solved: nil, Eliminated: nfound, Pattern: as.pattern(a.cells.or(b.cells).or(c.cells))})
+			}
+		}
+	}
+
+	return d.result()
+}
+
+// Death blossom: a stem cell and for each of its candidates a set having
+// the number only in cells seeing the stem. Whichever the stem is, one
+// set is locked, so a number z in every set is eliminated from cells
+// seeing all z of the sets.
+func (s *Sudoku) findDeathBlossoms() finderResult {
+	d := deductions{}
+
+	as := s.findALSs()
+
+	for stem := 0; stem < sudokuGridSize; stem++ {
+		smask := as.masks[stem]
+		if n := bits.OnesCount16(smask); n < 2 || n > 3 {
+			continue
+		}
+
+		digits := []int{}
+		petals := [][]*als{}
+
+		for n := 1; n <= sudokuNumbers; n++ {
+			if smask&(1<<uint(n)) == 0 {
+				continue
+			}
+
+			sets := []*als{}
+			for _, a := range as.sets {
+				if !a.cells.has(stem) && a.hasDigit(int8(n)) &&
+					a.digits[n].subsetOf(peerSets[stem]) && a.mask&^smask != 0 {
+					sets = append(sets, a)
+				}
+			}
+
+			digits = append(digits, n)
+			petals = append(petals, sets)
+		}
+
+		var stemCell cellSet
+		stemCell.add(stem)
+
+		chosen := make([]*als, len(digits))
+
+		var visit func(k int, used cellSet, common uint16)
+		visit = func(k int, used cellSet, common uint16) {
+			if common == 0 {
+				return
+			}
+
+			if k == len(digits) {
+				nfound := as.lockedElims(common, chosen...)
+				d.add(finderResult{Solved: nil, Eliminated: nfound, Pattern: as.pattern(used.or(stemCell))})
+				return
+			}
+
+			for _, a := range petals[k] {
+				if !a.cells.and(used).empty() {
+					continue
+				}
+
+				chosen[k] = a
+				visit(k+1, used.or(a.cells), common&a.mask)
+			}
+		}
+
+		visit(0, cellSet{}, ^smask&0x3fe)
+	}
+
+	return d.result()
+}
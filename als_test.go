@@ -0,0 +1,43 @@
+package sudoku_test
+
+import (
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+func TestALSXZ(t *testing.T) {
+	grid := "003900601000000040000010270460001000200790068300080000000003000000500906001000700"
+
+	step := stalledHint(t, grid, "als-xz")
+	assert.DeepEqual(t, sudoku.CellList{cell(9, 2, 7, 2)}, step.Eliminated)
+}
+
+func TestALSXZDoublyLinked(t *testing.T) {
+	// r4c3 r4c6 (145) and r6c1 r6c3 r6c5 (1567) are linked by 1 and 5,
+	// the 7 in r5c1 sees all 7s of the second set
+	grid := "020000000100008040000620803080070300002500000030000004040090006309082000000050001"
+
+	step := stalledHint(t, grid, "als-xz")
+	assert.DeepEqual(t, sudoku.CellList{
+		cell(1, 7, 3, 6), cell(1, 8, 3, 5), cell(1, 9, 3, 5), cell(4, 1, 4, 4), cell(4, 6, 5, 4),
+		cell(4, 8, 6, 6), cell(5, 1, 4, 4), cell(5, 1, 4, 7), cell(5, 8, 6, 6), cell(5, 8, 6, 9),
+		cell(6, 1, 4, 6), cell(6, 7, 6, 6), cell(6, 7, 6, 7)}, step.Eliminated)
+}
+
+func TestALSXYWing(t *testing.T) {
+	grid := "649500000000000000005608007000803009000040008000009504007005600050020800830000270"
+
+	step := stalledHint(t, grid, "als-xy-wing")
+	assert.DeepEqual(t, sudoku.CellList{cell(4, 1, 4, 7), cell(5, 6, 5, 1)}, step.Eliminated)
+}
+
+func TestDeathBlossom(t *testing.T) {
+	// The stem r8c5 (248) with the petals r9c4 (27), r8c8 (47) and r8c7
+	// (78), r9c7 and r9c9 see the 7 of every petal
+	grid := "003400008806079000070005000000300080000000400700094103000510230000006001090000050"
+
+	step := stalledHint(t, grid, "death blossom")
+	assert.DeepEqual(t, sudoku.CellList{cell(9, 7, 9, 7), cell(9, 9, 9, 7)}, step.Eliminated)
+}
@@ -0,0 +1,316 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"math/bits"
+)
+
+// Corners of the rectangles in two rows, two columns and two boxes, in
+// order top left, top right, bottom left, bottom right
+var rectangles = func() [][4]int {
+	res := [][4]int{}
+
+	for r1 := 0; r1 < sudokuNumbers; r1++ {
+		for r2 := r1 + 1; r2 < sudokuNumbers; r2++ {
+			for c1 := 0; c1 < sudokuNumbers; c1++ {
+				for c2 := c1 + 1; c2 < sudokuNumbers; c2++ {
+					if (r1/3 == r2/3) == (c1/3 == c2/3) {
+						continue
+					}
+
+					res = append(res, [4]int{
+						r1*sudokuNumbers + c1, r1*sudokuNumbers + c2,
+						r2*sudokuNumbers + c1, r2*sudokuNumbers + c2,
+					})
+				}
+			}
+		}
+	}
+
+	return res
+}()
+
+// Rotations of a rectangle: the corner, the ones sharing a row and a
+// column with it and the opposite one
+var rectangleRotations = [4][4]int{{0, 1, 2, 3}, {1, 0, 3, 2}, {2, 3, 0, 1}, {3, 2, 1, 0}}
+
+func maskDigits(mask uint16) []int8 {
+	res := []int8{}
+
+	for n := int8(1); n <= sudokuNumbers; n++ {
+		if mask&(1<<uint(n)) != 0 {
+			res = append(res, n)
+		}
+	}
+
+	return res
+}
+
+func (s *Sudoku) maskCandidates(idx int, mask uint16) CellList {
+	pos := s.Solved[idx].Pos
+
+	return s.Candidates.Filter(func(c Cell) bool {
+		return c.Pos == pos && mask&(1<<uint(c.Value)) != 0
+	})
+}
+
+// Candidates of the cells seeing both cells
+func (s *Sudoku) commonPeerCandidates(a, b int, mask uint16) CellList {
+	peers := peerSets[a].and(peerSets[b])
+
+	return s.Candidates.Filter(func(c Cell) bool {
+		return mask&(1<<uint(c.Value)) != 0 && peers.has(c.Pos.index())
+	})
+}
+
+func (s *Sudoku) rectanglePattern(cells []int) CellList {
+	set := cellSet{}
+	for _, idx := range cells {
+		set.add(idx)
+	}
+
+	pattern := s.Solved.Filter(func(c Cell) bool {
+		return c.Value != 0 && set.has(c.Pos.index())
+	})
+
+	return append(pattern, s.Candidates.Filter(func(c Cell) bool {
+		return set.has(c.Pos.index())
+	})...)
+}
+
+// An avoidable rectangle is like a unique rectangle, but solved cells
+// that are not givens take part: if the values could be swapped between
+// the corners, the puzzle would have two solutions.
+func (s *Sudoku) findAvoidableRectangles() finderResult {
+	masks := candidateMasks(s.Candidates)
+
+	// Value of a solved cell that is not a given
+	solved := func(idx int) int8 {
+		if s.givens[idx] {
+			return 0
+		}
+		return s.Solved[idx].Value
+	}
+
+	for _, rect := range rectangles {
+		for _, rot := range rectangleRotations {
+			u, row, col, opp := rect[rot[0]], rect[rot[1]], rect[rot[2]], rect[rot[3]]
+
+			// Type 1: the opposite corner is a and the others b, so u
+			// can not be a
+			a, b := solved(opp), solved(row)
+			if masks[u] != 0 && a != 0 && b != 0 && a != b && solved(col) == b {
+				if found := s.maskCandidates(u, 1<<uint(a)); len(found) > 0 {
+					return finderResult{Solved: nil, Eliminated: found, Pattern: s.rectanglePattern(rect[:])}
+				}
+			}
+
+			// u and v sharing a row or a column are unsolved, the
+			// corners opposite to them are a and b. To avoid u being a
+			// and v b, one of them has to be an extra candidate.
+			for _, v := range []int{row, col} {
+				vopp := row
+				if v == row {
+					vopp = col
+				}
+
+				a, b := solved(opp), solved(vopp)
+				ma, mb := uint16(1)<<uint(a), uint16(1)<<uint(b)
+
+				if a == 0 || b == 0 || a == b || masks[u]&ma == 0 || masks[v]&mb == 0 {
+					continue
+				}
+
+				eu, ev := masks[u]&^ma, masks[v]&^mb
+				if eu == 0 || ev == 0 {
+					continue
+				}
+
+				// Type 2: the same single extra candidate
+				if eu == ev && bits.OnesCount16(eu) == 1 {
+					if found := s.commonPeerCandidates(u, v, eu); len(found) > 0 {
+						return finderResult{Solved: nil, Eliminated: found, Pattern: s.rectanglePattern(rect[:])}
+					}
+				}
+
+				// Type 3: the extra candidates form a naked subset with
+				// other cells of a house
+				if u > v {
+					continue
+				}
+
+				if res := s.rectangleSubset(masks, u, v, eu|ev); len(res.Eliminated) > 0 {
+					res.Pattern = append(s.rectanglePattern(rect[:]), res.Pattern...)
+					return res
+				}
+			}
+		}
+	}
+
+	return finderResult{Solved: nil, Eliminated: nil}
+}
+
+// rectangleSubset looks for a naked subset formed by the extra candidates
+// of cells u and v, acting as one cell, and other cells of a house shared
+// by them.
+func (s *Sudoku) rectangleSubset(masks [sudokuGridSize]uint16, u, v int, extra uint16) finderResult {
+	none := finderResult{Solved: nil, Eliminated: nil}
+
+	hu, hv := houses(indexPos(u)), houses(indexPos(v))
+
+	for k := range hu {
+		if hu[k] != hv[k] {
+			continue
+		}
+
+		others := []int{}
+		for _, idx := range houseSets[hu[k]].indexes() {
+			if idx != u && idx != v && masks[idx] != 0 {
+				others = append(others, idx)
+			}
+		}
+
+		for size := 1; size <= 3 && size < len(others); size++ {
+			combs := newCombination(len(others), size)
+			for {
+				var idxs intList = combs.next()
+				if idxs == nil {
+					break
+				}
+
+				mask := extra
+				subset := cellSet{}
+				for _, i := range idxs {
+					mask |= masks[others[i]]
+					subset.add(others[i])
+				}
+
+				if bits.OnesCount16(mask) != size+1 {
+					continue
+				}
+
+				found := CellList{}
+				for _, idx := range others {
+					if !subset.has(idx) {
+						found = append(found, s.maskCandidates(idx, mask)...)
+					}
+				}
+
+				if len(found) > 0 {
+					pattern := CellList{}
+					for _, idx := range subset.indexes() {
+						pattern = append(pattern, s.maskCandidates(idx, mask)...)
+					}
+
+					return finderResult{Solved: nil, Eliminated: found, Pattern: pattern}
+				}
+			}
+		}
+	}
+
+	return none
+}
+
+// Cells of the 2x3 and 3x2 patterns: two rows of a band and a column in
+// each of three stacks, or the other way around
+var extendedRectangles = func() [][6]int {
+	res := [][6]int{}
+
+	for transpose := 0; transpose < 2; transpose++ {
+		for band := 0; band < 3; band++ {
+			for _, lines := range [][2]int{{0, 1}, {0, 2}, {1, 2}} {
+				for c1 := 0; c1 < 3; c1++ {
+					for c2 := 3; c2 < 6; c2++ {
+						for c3 := 6; c3 < 9; c3++ {
+							var cells [6]int
+							i := 0
+
+							for _, l := range lines {
+								for _, c := range []int{c1, c2, c3} {
+									r := band*3 + l
+									if transpose == 1 {
+										cells[i] = c*sudokuNumbers + r
+									} else {
+										cells[i] = r*sudokuNumbers + c
+									}
+									i++
+								}
+							}
+
+							res = append(res, cells)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return res
+}()
+
+// An extended unique rectangle is six unsolved cells in two rows and three
+// columns, or the other way around, in three boxes. If their candidates
+// were only three numbers, the rows could be swapped making two solutions.
+// With one cell having extra candidates it can not be any of the three
+// (type 1), with two cells having the same single extra candidate one of
+// them is that number (type 2).
+func (s *Sudoku) findExtendedUniqueRectangles() finderResult {
+	masks := candidateMasks(s.Candidates)
+
+	for _, ext := range extendedRectangles {
+		unsolved := true
+		for _, idx := range ext {
+			unsolved = unsolved && masks[idx] != 0
+		}
+
+		if !unsolved {
+			continue
+		}
+
+		for i := range ext {
+			for j := i; j < len(ext); j++ {
+				core := uint16(0)
+				for k, idx := range ext {
+					if k != i && k != j {
+						core |= masks[idx]
+					}
+				}
+
+				if bits.OnesCount16(core) != 3 {
+					continue
+				}
+
+				ei, ej := masks[ext[i]]&^core, masks[ext[j]]&^core
+
+				var found CellList
+				switch {
+				case i == j && ei != 0:
+					found = s.maskCandidates(ext[i], core)
+				case i != j && ei == ej && bits.OnesCount16(ei) == 1:
+					found = s.commonPeerCandidates(ext[i], ext[j], ei)
+				}
+
+				if len(found) > 0 {
+					return finderResult{Solved: nil, Eliminated: found, Pattern: s.rectanglePattern(ext[:])}
+				}
+			}
+		}
+	}
+
+	return finderResult{Solved: nil, Eliminated: nil}
+}
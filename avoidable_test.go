@@ -0,0 +1,89 @@
+package sudoku_test
+
+import (
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+func TestGiven(t *testing.T) {
+	s, err := sudoku.NewSudoku("000000708700300000925840000004930005200050000308000020000106004400000300009000860")
+	assert.NilError(t, err)
+
+	assert.Assert(t, s.Given(1, 7))
+	assert.Assert(t, !s.Given(1, 1))
+	assert.Assert(t, !s.Given(0, 1))
+
+	s.Solve()
+	assert.Assert(t, !s.Given(1, 1))
+}
+
+func TestAvoidableRectangle(t *testing.T) {
+	tests := []struct {
+		grid       string
+		placed     sudoku.CellList
+		eliminated sudoku.CellList
+	}{
+		// Type 1: r3c8 and r5c9 are 3 and r5c8 is 1, so r3c9 can't be 1
+		{"000000708700300000925840000004930005200050000308000020000106004400000300009000860",
+			sudoku.CellList{cell(3, 8, 3, 3), cell(5, 8, 6, 1), cell(5, 9, 6, 3)},
+			sudoku.CellList{cell(3, 9, 3, 1)}},
+		// Type 2: r1c6 is 5 and r7c6 7, r1c4 (47) and r7c4 (45) can't
+		// be 7 and 5, one of them is 4
+		{"390080201000000000015060408006270080000000002900010004680000100040100850000003000",
+			sudoku.CellList{cell(1, 6, 2, 5), cell(7, 6, 8, 7)},
+			sudoku.CellList{cell(2, 4, 2, 4)}},
+		// Type 3: r3c8 is 3 and r5c8 1, the extra candidates of r3c9
+		// (16) and r5c9 (367) form a naked triple (67) with r6c9 (67)
+		{"000000708700300000925840000004930005200050000308000020000106004400000300009000860",
+			sudoku.CellList{cell(3, 8, 3, 3), cell(5, 8, 6, 1)},
+			sudoku.CellList{cell(8, 9, 9, 7), cell(9, 9, 9, 7)}},
+	}
+
+	for _, test := range tests {
+		s := forcingState(t, test.grid)
+
+		solution, ok := s.Solution()
+		assert.Assert(t, ok)
+
+		// Placed as if solved, not given
+		assert.NilError(t, s.ApplyStep(sudoku.Step{Solved: test.placed}))
+
+		step, ok, err := s.HintWith([]string{"avoidable rectangle"})
+		assert.NilError(t, err)
+		assert.Assert(t, ok)
+		assert.DeepEqual(t, test.eliminated, step.Eliminated)
+
+		assert.NilError(t, s.ApplyStep(step))
+		assertSound(t, s, solution)
+	}
+}
+
+func TestAvoidableRectangleGivens(t *testing.T) {
+	// The type 1 pattern of givens could be the only way to place them
+	s, err := sudoku.NewSudoku("000000708700300000925840030004930005200050013308000020000106004400000300009000860")
+	assert.NilError(t, err)
+	assert.Assert(t, s.Given(3, 8) && s.Given(5, 8) && s.Given(5, 9))
+
+	step, ok, err := s.HintWith([]string{"avoidable rectangle"})
+	assert.NilError(t, err)
+	assert.Assert(t, !ok || !step.Eliminated.Any(func(c sudoku.Cell) bool {
+		return c == cell(3, 9, 3, 1)
+	}))
+}
+
+func TestExtendedUniqueRectangle(t *testing.T) {
+	s := forcingState(t, "000510000090002080005300406750000009030070060100006200070603000009008004500000000")
+
+	solution, ok := s.Solution()
+	assert.Assert(t, ok)
+
+	step, ok, err := s.HintWith([]string{"extended unique rectangle"})
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, sudoku.CellList{cell(7, 9, 9, 5), cell(7, 9, 9, 8)}, step.Eliminated)
+
+	assert.NilError(t, s.ApplyStep(step))
+	assertSound(t, s, solution)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Largest backdoor size searched
+const backdoorMaxSize = 2
+
+// BackdoorOptions control Backdoors.
+type BackdoorOptions struct {
+	// Names of the strategies to solve with, nil means the default
+	// strategies
+	Strategies []string
+	// Largest backdoor size to search, 1 or 2, zero means 2
+	MaxSize int
+}
+
+// BackdoorResult lists the smallest backdoors found.
+type BackdoorResult struct {
+	// The strategies solve the puzzle without a backdoor
+	Solved bool `json:"solved"`
+	// Cells with their solution values, all of the same size. Empty if
+	// the puzzle is solved as is or there is no backdoor up to MaxSize.
+	Backdoors []CellList `json:"backdoors,omitempty"`
+}
+
+// Backdoors searches the smallest sets of cells whose solution values,
+// once given, let the strategies solve the puzzle. The solution found by
+// brute force is used as the oracle.
+func Backdoors(grid string, opts BackdoorOptions) (BackdoorResult, error) {
+	return BackdoorsContext(context.Background(), grid, opts)
+}
+
+// BackdoorsContext is like Backdoors, but stops when ctx is done.
+func BackdoorsContext(ctx context.Context, grid string, opts BackdoorOptions) (BackdoorResult, error) {
+	res := BackdoorResult{}
+
+	maxSize := opts.MaxSize
+	if maxSize == 0 {
+		maxSize = backdoorMaxSize
+	}
+	if maxSize < 1 || maxSize > backdoorMaxSize {
+		return res, fmt.Errorf("Invalid backdoor size %d", opts.MaxSize)
+	}
+
+	finders, err := selectStrategies(opts.Strategies)
+	if err != nil {
+		return res, err
+	}
+
+	s, err := NewSudoku(grid)
+	if err != nil {
+		return res, err
+	}
+
+	solutions := bruteForceSolutions(s.givenValues(), 2)
+	if len(solutions) != 1 {
+		return res, ErrNotUnique
+	}
+	solution := solutions[0]
+
+	if s.solve(ctx, finders, SolveOptions{}).Status == StatusSolved {
+		res.Solved = true
+		return res, nil
+	}
+
+	// Giving a value the strategies already found does not help, so
+	// continue from the stalled state with its unsolved cells
+	cells := []int{}
+	for i, cell := range s.Solved {
+		if cell.Value == 0 {
+			cells = append(cells, i)
+		}
+	}
+
+	for size := 1; size <= maxSize && len(res.Backdoors) == 0; size++ {
+		combs := newCombination(len(cells), size)
+
+		for {
+			if err := ctx.Err(); err != nil {
+				return res, err
+			}
+
+			var idxs intList = combs.next()
+			if idxs == nil {
+				break
+			}
+
+			c := s.clone()
+			door := CellList{}
+
+			for _, idx := range idxs {
+				i := cells[idx]
+				door = append(door, Cell{Pos: indexPos(i), Value: solution[i]})
+				c.givens[i] = true
+			}
+
+			c.apply("backdoor", finderResult{Solved: door, Eliminated: nil})
+
+			if c.solve(ctx, finders, SolveOptions{}).Status == StatusSolved {
+				res.Backdoors = append(res.Backdoors, door)
+			}
+		}
+	}
+
+	sort.Slice(res.Backdoors, func(i, j int) bool {
+		a, b := res.Backdoors[i], res.Backdoors[j]
+		for k := range a {
+			if a[k].Pos != b[k].Pos {
+				return a[k].Pos.index() < b[k].Pos.index()
+			}
+		}
+		return false
+	})
+
+	return res, ctx.Err()
+}
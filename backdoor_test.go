@@ -0,0 +1,104 @@
+package sudoku_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+// assertBackdoor checks that giving the cells lets the strategies solve
+// the grid
+func assertBackdoor(t *testing.T, grid string, door sudoku.CellList, names []string) {
+	t.Helper()
+
+	runes := []rune(grid)
+	for _, c := range door {
+		runes[int(c.Pos.Row-1)*9+int(c.Pos.Column-1)] = '0' + rune(c.Value)
+	}
+
+	s, err := sudoku.NewSudoku(string(runes))
+	assert.NilError(t, err)
+
+	res, err := s.SolveContext(context.Background(), sudoku.SolveOptions{Strategies: names})
+	assert.NilError(t, err)
+	assert.Equal(t, sudoku.StatusSolved, res.Status, door)
+}
+
+func TestBackdoors(t *testing.T) {
+	grid := "000921003009000060000000500080403006007000800500700040003000000020000700800195000"
+
+	res, err := sudoku.Backdoors(grid, sudoku.BackdoorOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, res.Solved)
+	assert.Equal(t, 0, len(res.Backdoors))
+
+	res, err = sudoku.Backdoors("000704005020010070000080002090006250600070008053200010400090000030060090200407000",
+		sudoku.BackdoorOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, !res.Solved)
+	assert.Equal(t, 34, len(res.Backdoors))
+
+	names := baseStrategies
+	res, err = sudoku.Backdoors(grid, sudoku.BackdoorOptions{Strategies: names})
+	assert.NilError(t, err)
+	assert.Equal(t, 13, len(res.Backdoors))
+	assert.DeepEqual(t, sudoku.CellList{cell(1, 2, 1, 6)}, res.Backdoors[0])
+
+	for _, door := range res.Backdoors {
+		assert.Equal(t, 1, len(door))
+		assertBackdoor(t, grid, door, names)
+	}
+
+	singles := []string{"singles (simple)"}
+	res, err = sudoku.Backdoors(grid, sudoku.BackdoorOptions{Strategies: singles})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []sudoku.CellList{{cell(4, 1, 4, 1)}, {cell(7, 7, 9, 1)}}, res.Backdoors)
+}
+
+func TestBackdoorsPairs(t *testing.T) {
+	grid := "009000820000070005000009040005000200000100000082090056020450031006300400040010000"
+	singles := []string{"singles (simple)"}
+
+	res, err := sudoku.Backdoors(grid, sudoku.BackdoorOptions{Strategies: singles, MaxSize: 1})
+	assert.NilError(t, err)
+	assert.Assert(t, !res.Solved)
+	assert.Equal(t, 0, len(res.Backdoors))
+
+	res, err = sudoku.Backdoors(grid, sudoku.BackdoorOptions{Strategies: singles})
+	assert.NilError(t, err)
+	assert.Equal(t, 9, len(res.Backdoors))
+
+	for _, door := range res.Backdoors {
+		assert.Equal(t, 2, len(door))
+		assertBackdoor(t, grid, door, singles)
+	}
+}
+
+func TestBackdoorsSolved(t *testing.T) {
+	res, err := sudoku.Backdoors("000000708700300000925840000004930005200050000308000020000106004400000300009000860",
+		sudoku.BackdoorOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, res.Solved)
+	assert.Equal(t, 0, len(res.Backdoors))
+}
+
+func TestBackdoorsErrors(t *testing.T) {
+	grid := "000921003009000060000000500080403006007000800500700040003000000020000700800195000"
+
+	_, err := sudoku.Backdoors(grid, sudoku.BackdoorOptions{MaxSize: 3})
+	assert.ErrorContains(t, err, "Invalid backdoor size")
+
+	_, err = sudoku.Backdoors(grid, sudoku.BackdoorOptions{Strategies: []string{"guessing"}})
+	assert.ErrorContains(t, err, "Unknown strategy")
+
+	_, err = sudoku.Backdoors("000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		sudoku.BackdoorOptions{})
+	assert.Equal(t, sudoku.ErrNotUnique, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = sudoku.BackdoorsContext(ctx, grid, sudoku.BackdoorOptions{})
+	assert.Equal(t, context.Canceled, err)
+}
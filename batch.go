@@ -0,0 +1,271 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// BatchFormat selects the output format of BatchSolve.
+type BatchFormat int
+
+const (
+	// BatchText writes tab separated lines.
+	BatchText BatchFormat = iota
+	// BatchJSON writes one JSON object per line.
+	BatchJSON
+)
+
+// BatchOptions control BatchSolve.
+type BatchOptions struct {
+	// Number of puzzles solved concurrently, zero means runtime.NumCPU()
+	Workers int
+	Format  BatchFormat
+	// Time limit per puzzle, zero means no limit
+	Timeout time.Duration
+	// Options used for every puzzle, the deadline is ignored
+	Solve SolveOptions
+	// Statistics collector shared by all puzzles, may be nil
+	Stats *Stats
+}
+
+// BatchResult is the result of a single puzzle.
+type BatchResult struct {
+	Line   int    `json:"line"`
+	Puzzle string `json:"puzzle"`
+	// Nil if the puzzle could not be parsed
+	Status *SolveStatus `json:"status,omitempty"`
+	Grid   string       `json:"grid,omitempty"`
+	Rating *Rating      `json:"rating,omitempty"`
+	Error  string       `json:"error,omitempty"`
+}
+
+// BatchSummary summarizes a BatchSolve run.
+type BatchSummary struct {
+	Puzzles        int           `json:"puzzles"`
+	Solved         int           `json:"solved"`
+	Stalled        int           `json:"stalled"`
+	Cancelled      int           `json:"cancelled"`
+	Contradictions int           `json:"contradictions"`
+	Errors         int           `json:"errors"`
+	Time           time.Duration `json:"time_ns"`
+}
+
+type batchJob struct {
+	line   int
+	puzzle string
+	result chan BatchResult
+}
+
+// Lines may use '.' for empty cells, anything after the first field is
+// ignored.
+func normalizePuzzle(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return strings.Replace(fields[0], ".", "0", -1)
+}
+
+func solveBatchJob(job batchJob, opts BatchOptions) BatchResult {
+	res := BatchResult{Line: job.line, Puzzle: job.puzzle}
+
+	s, err := NewSudoku(normalizePuzzle(job.puzzle))
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	if opts.Stats != nil {
+		s.SetStats(opts.Stats)
+	}
+
+	ctx := context.Background()
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	sopts := opts.Solve
+	sopts.Deadline = time.Time{}
+
+	rating, err := s.rate(ctx, sopts)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	res.Status = &rating.Status
+	res.Grid = s.GetGridString()
+	res.Rating = &rating
+
+	return res
+}
+
+func (sum *BatchSummary) add(res BatchResult) {
+	sum.Puzzles++
+
+	if res.Error != "" {
+		sum.Errors++
+		return
+	}
+
+	switch *res.Status {
+	case StatusSolved:
+		sum.Solved++
+	case StatusStalled:
+		sum.Stalled++
+	case StatusCancelled:
+		sum.Cancelled++
+	case StatusContradiction:
+		sum.Contradictions++
+	}
+}
+
+func writeBatchResult(w io.Writer, res BatchResult, format BatchFormat) error {
+	if format == BatchJSON {
+		return json.NewEncoder(w).Encode(res)
+	}
+
+	var err error
+	if res.Error != "" {
+		_, err = fmt.Fprintf(w, "%s\terror\t%s\n", res.Puzzle, res.Error)
+	} else {
+		_, err = fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n",
+			res.Puzzle, *res.Status, res.Grid, res.Rating.Level, res.Rating.Hardest)
+	}
+
+	return err
+}
+
+func writeBatchSummary(w io.Writer, sum BatchSummary, format BatchFormat) error {
+	if format == BatchJSON {
+		return json.NewEncoder(w).Encode(struct {
+			Summary BatchSummary `json:"summary"`
+		}{sum})
+	}
+
+	_, err := fmt.Fprintf(w,
+		"# puzzles %d, solved %d, stalled %d, cancelled %d, contradictions %d, errors %d, time %v\n",
+		sum.Puzzles, sum.Solved, sum.Stalled, sum.Cancelled, sum.Contradictions, sum.Errors, sum.Time)
+
+	return err
+}
+
+// BatchSolve solves the puzzles read from r, one per line, using a pool
+// of workers. A result line is written to w for every puzzle in input
+// order, followed by a summary. Empty lines and lines starting with '#'
+// are skipped. Unknown strategy names fail the whole batch before any
+// input is read.
+func BatchSolve(r io.Reader, w io.Writer, opts BatchOptions) (BatchSummary, error) {
+	start := time.Now()
+
+	if _, err := selectStrategies(opts.Solve.Strategies); err != nil {
+		return BatchSummary{}, err
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	jobs := make(chan batchJob)
+	order := make(chan batchJob, 2*workers)
+	quit := make(chan struct{})
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range jobs {
+				job.result <- solveBatchJob(job, opts)
+			}
+		}()
+	}
+
+	readErr := make(chan error, 1)
+
+	go func() {
+		defer close(order)
+		defer close(jobs)
+
+		scanner := bufio.NewScanner(r)
+		line := 0
+
+		for scanner.Scan() {
+			line++
+
+			puzzle := strings.TrimSpace(scanner.Text())
+			if puzzle == "" || strings.HasPrefix(puzzle, "#") {
+				continue
+			}
+
+			job := batchJob{line: line, puzzle: puzzle, result: make(chan BatchResult, 1)}
+
+			select {
+			case order <- job:
+			case <-quit:
+				readErr <- nil
+				return
+			}
+
+			select {
+			case jobs <- job:
+			case <-quit:
+				readErr <- nil
+				return
+			}
+		}
+
+		readErr <- scanner.Err()
+	}()
+
+	sum := BatchSummary{}
+	bw := bufio.NewWriter(w)
+
+	for job := range order {
+		res := <-job.result
+		sum.add(res)
+
+		if err := writeBatchResult(bw, res, opts.Format); err != nil {
+			close(quit)
+			for range order {
+			}
+			return sum, err
+		}
+	}
+
+	if err := <-readErr; err != nil {
+		bw.Flush()
+		return sum, err
+	}
+
+	sum.Time = time.Since(start)
+
+	if err := writeBatchSummary(bw, sum, opts.Format); err != nil {
+		return sum, err
+	}
+
+	return sum, bw.Flush()
+}
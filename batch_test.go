@@ -0,0 +1,91 @@
+package sudoku_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+var batchPuzzles = []string{
+	"000040700500780020070002006810007900460000051009600078900800010080064009002050000",
+	"700600008800030000090000310006740005005806900400092100087000020000060009600008001",
+	"...7.4..5.2..1..7.....8...2.9...625.6...7...8.532...1.4...9.....3..6..9.2..4.7...",
+	"014600300050000007090840100000400800600050009007009000008016030300000010009008570",
+	"CAT921003009000060000000500080403006007000800500700040003000000020000700800195000",
+}
+
+func TestBatchSolveText(t *testing.T) {
+	input := "# puzzles\n\n" + strings.Join(batchPuzzles, "\n") + "\n"
+
+	var out bytes.Buffer
+	sum, err := sudoku.BatchSolve(strings.NewReader(input), &out, sudoku.BatchOptions{Workers: 3})
+	assert.NilError(t, err)
+
+	assert.Equal(t, 5, sum.Puzzles)
+	assert.Equal(t, 3, sum.Solved)
+	assert.Equal(t, 1, sum.Stalled)
+	assert.Equal(t, 1, sum.Errors)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, len(batchPuzzles)+1, len(lines))
+
+	for i, puzzle := range batchPuzzles {
+		fields := strings.Split(lines[i], "\t")
+		assert.Equal(t, puzzle, fields[0])
+	}
+
+	assert.Assert(t, strings.HasPrefix(lines[0], batchPuzzles[0]+"\tsolved\t628341795"))
+	assert.Assert(t, strings.Contains(lines[2], "\tstalled\t"))
+	assert.Equal(t, batchPuzzles[4]+"\terror\tInvalid rune 'C' in grid", lines[4])
+	assert.Assert(t, strings.HasPrefix(lines[5], "# puzzles 5, solved 3"))
+}
+
+func TestBatchSolveJSONOrder(t *testing.T) {
+	var input strings.Builder
+	for i := 0; i < 5; i++ {
+		for _, puzzle := range batchPuzzles[:4] {
+			input.WriteString(puzzle + "\n")
+		}
+	}
+
+	stats := sudoku.NewStats()
+
+	var out bytes.Buffer
+	sum, err := sudoku.BatchSolve(strings.NewReader(input.String()), &out,
+		sudoku.BatchOptions{Workers: 4, Format: sudoku.BatchJSON, Stats: stats})
+	assert.NilError(t, err)
+	assert.Equal(t, 20, sum.Puzzles)
+
+	total, solved := stats.Puzzles()
+	assert.Equal(t, 20, total)
+	assert.Equal(t, 15, solved)
+
+	scanner := bufio.NewScanner(&out)
+	line := 0
+	for line < 20 && scanner.Scan() {
+		var res sudoku.BatchResult
+		assert.NilError(t, json.Unmarshal(scanner.Bytes(), &res))
+
+		line++
+		assert.Equal(t, line, res.Line)
+		assert.Equal(t, batchPuzzles[(line-1)%4], res.Puzzle)
+		assert.Assert(t, res.Rating != nil && res.Rating.Level > 0)
+	}
+	assert.Equal(t, 20, line)
+
+	assert.Assert(t, scanner.Scan())
+	assert.Assert(t, strings.HasPrefix(scanner.Text(), `{"summary":{"puzzles":20,`))
+}
+
+func TestBatchSolveUnknownStrategy(t *testing.T) {
+	var out bytes.Buffer
+	_, err := sudoku.BatchSolve(strings.NewReader(strings.Join(batchPuzzles, "\n")), &out,
+		sudoku.BatchOptions{Solve: sudoku.SolveOptions{Strategies: []string{"guessing"}}})
+	assert.Error(t, err, "Unknown strategy 'guessing'")
+	assert.Equal(t, "", out.String())
+}
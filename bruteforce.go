@@ -0,0 +1,252 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"math/rand"
+)
+
+const allNumbers uint16 = 0x3fe
+
+// Number of search nodes between checks for cancellation
+const bruteForceCheckInterval = 1024
+
+// Backtracking search used as an oracle, for uniqueness checks and for
+// generating puzzles. Not used by Solve.
+type bruteForce struct {
+	values [sudokuGridSize]int8
+
+	rows    [sudokuNumbers]uint16
+	columns [sudokuNumbers]uint16
+	boxes   [sudokuNumbers]uint16
+
+	limit     int
+	solutions [][sudokuGridSize]int8
+
+	rnd *rand.Rand
+
+	ctx   context.Context
+	nodes int
+	err   error
+}
+
+func boxIndex(idx int) int {
+	row, col := idx/sudokuNumbers, idx%sudokuNumbers
+	return (row/sudokuBoxes)*sudokuBoxes + col/sudokuBoxes
+}
+
+// newBruteForce returns nil if the values conflict with each other.
+func newBruteForce(values [sudokuGridSize]int8) *bruteForce {
+	b := bruteForce{values: values}
+
+	for i, v := range values {
+		if v == 0 {
+			continue
+		}
+
+		bit := uint16(1) << uint(v)
+		row, col, box := i/sudokuNumbers, i%sudokuNumbers, boxIndex(i)
+
+		if (b.rows[row]|b.columns[col]|b.boxes[box])&bit != 0 {
+			return nil
+		}
+
+		b.rows[row] |= bit
+		b.columns[col] |= bit
+		b.boxes[box] |= bit
+	}
+
+	return &b
+}
+
+func (s Sudoku) gridValues() [sudokuGridSize]int8 {
+	var values [sudokuGridSize]int8
+
+	for i, cell := range s.Solved {
+		values[i] = cell.Value
+	}
+
+	return values
+}
+
+func (s Sudoku) givenValues() [sudokuGridSize]int8 {
+	var values [sudokuGridSize]int8
+
+	for i, cell := range s.Solved {
+		if s.givens[i] {
+			values[i] = cell.Value
+		}
+	}
+
+	return values
+}
+
+func (b *bruteForce) search() bool {
+	if b.ctx != nil && b.nodes%bruteForceCheckInterval == 0 {
+		if err := b.ctx.Err(); err != nil {
+			b.err = err
+			return true
+		}
+	}
+	b.nodes++
+
+	best := -1
+	var bestMask uint16
+	bestCount := sudokuNumbers + 1
+
+	for i, v := range b.values {
+		if v != 0 {
+			continue
+		}
+
+		row, col, box := i/sudokuNumbers, i%sudokuNumbers, boxIndex(i)
+		mask := allNumbers &^ (b.rows[row] | b.columns[col] | b.boxes[box])
+		count := bits.OnesCount16(mask)
+
+		if count == 0 {
+			return false
+		}
+
+		if count < bestCount {
+			best, bestMask, bestCount = i, mask, count
+			if count == 1 {
+				break
+			}
+		}
+	}
+
+	if best < 0 {
+		b.solutions = append(b.solutions, b.values)
+		return len(b.solutions) >= b.limit
+	}
+
+	nums := make([]int8, 0, bestCount)
+	for n := int8(1); n <= sudokuNumbers; n++ {
+		if bestMask&(1<<uint(n)) != 0 {
+			nums = append(nums, n)
+		}
+	}
+
+	if b.rnd != nil {
+		b.rnd.Shuffle(len(nums), func(i, j int) {
+			nums[i], nums[j] = nums[j], nums[i]
+		})
+	}
+
+	row, col, box := best/sudokuNumbers, best%sudokuNumbers, boxIndex(best)
+
+	for _, n := range nums {
+		bit := uint16(1) << uint(n)
+
+		b.values[best] = n
+		b.rows[row] |= bit
+		b.columns[col] |= bit
+		b.boxes[box] |= bit
+
+		done := b.search()
+
+		b.values[best] = 0
+		b.rows[row] &^= bit
+		b.columns[col] &^= bit
+		b.boxes[box] &^= bit
+
+		if done {
+			return true
+		}
+	}
+
+	return false
+}
+
+func bruteForceSolutions(values [sudokuGridSize]int8, limit int) [][sudokuGridSize]int8 {
+	sols, _ := bruteForceSolutionsContext(context.Background(), values, limit)
+
+	return sols
+}
+
+// bruteForceSolutionsContext is like bruteForceSolutions, but gives up
+// with the error of ctx when it is done.
+func bruteForceSolutionsContext(ctx context.Context, values [sudokuGridSize]int8, limit int) ([][sudokuGridSize]int8, error) {
+	b := newBruteForce(values)
+	if b == nil {
+		return nil, nil
+	}
+
+	b.limit = limit
+	b.ctx = ctx
+	b.search()
+
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	return b.solutions, nil
+}
+
+func valuesString(values [sudokuGridSize]int8) string {
+	runes := make([]rune, sudokuGridSize)
+
+	for i, v := range values {
+		runes[i] = '0' + rune(v)
+	}
+
+	return string(runes)
+}
+
+// Solutions returns at most limit solutions of the grid found by
+// brute force. The limit must be positive.
+func Solutions(grid string, limit int) ([]string, error) {
+	return SolutionsContext(context.Background(), grid, limit)
+}
+
+// SolutionsContext is like Solutions, but stops when ctx is done.
+func SolutionsContext(ctx context.Context, grid string, limit int) ([]string, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("Invalid solution limit %d", limit)
+	}
+
+	s, err := NewSudoku(grid)
+	if err != nil {
+		return nil, err
+	}
+
+	sols, err := bruteForceSolutionsContext(ctx, s.gridValues(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []string{}
+	for _, sol := range sols {
+		res = append(res, valuesString(sol))
+	}
+
+	return res, nil
+}
+
+// Solution returns the unique solution of the givens found by brute
+// force, false if there is none or there are several.
+func (s Sudoku) Solution() (string, bool) {
+	sols := bruteForceSolutions(s.givenValues(), 2)
+	if len(sols) != 1 {
+		return "", false
+	}
+
+	return valuesString(sols[0]), true
+}
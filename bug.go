@@ -0,0 +1,173 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+// Limit for the cells with more than two candidates in BUG+n
+const bugMaxCells = 4
+
+// houseCounts returns how many times each number is a candidate in each
+// row, column and box, in that order.
+func (s *Sudoku) houseCounts() [3 * sudokuNumbers][sudokuNumbers + 1]int {
+	var res [3 * sudokuNumbers][sudokuNumbers + 1]int
+
+	funs := []cellGetter{s.getCandidateRow, s.getCandidateColumn, s.getCandidateBox}
+
+	for k, fun := range funs {
+		for i := int8(1); i <= sudokuNumbers; i++ {
+			for _, nc := range numberCounts(numbers(fun(i))) {
+				res[k*sudokuNumbers+int(i-1)][nc.num] = int(nc.count)
+			}
+		}
+	}
+
+	return res
+}
+
+func houses(pos Pos) [3]int {
+	return [3]int{
+		int(pos.Row - 1),
+		sudokuNumbers + int(pos.Column-1),
+		2*sudokuNumbers + int(pos.Box-1),
+	}
+}
+
+// findBUG looks for a bivalue universal grave: if every unsolved cell had
+// two candidates and every number was a candidate twice in each house
+// where it is missing, the puzzle would have two solutions. So one of the
+// extra candidates keeping the grid from that state must be true. With
+// one extra candidate it is placed (BUG+1), otherwise candidates seeing
+// all extra ones of a number, or other candidates of a cell having all of
+// them, are eliminated (BUG+n). Relies on the puzzle having a unique
+// solution.
+func (s *Sudoku) findBUG(maxExtra int) finderResult {
+	none := finderResult{Solved: nil, Eliminated: nil}
+
+	cands := s.cellCandidates(1, sudokuNumbers)
+	poly := []Pos{}
+
+	for _, pos := range sortedPositions(cands) {
+		nums := cands[pos]
+
+		if len(nums) < 2 {
+			return none
+		}
+
+		if len(nums) > 2 {
+			poly = append(poly, pos)
+		}
+	}
+
+	if len(poly) == 0 || len(poly) > bugMaxCells {
+		return none
+	}
+
+	counts := s.houseCounts()
+	extras := CellList{}
+
+	var visit func(k int) bool
+	visit = func(k int) bool {
+		if len(extras) > maxExtra {
+			return false
+		}
+
+		if k == len(poly) {
+			for _, hcounts := range counts {
+				for _, count := range hcounts {
+					if count != 0 && count != 2 {
+						return false
+					}
+				}
+			}
+			return true
+		}
+
+		pos := poly[k]
+		nums := cands[pos]
+		hs := houses(pos)
+
+		combs := newCombination(len(nums), len(nums)-2)
+		for {
+			var idxs intList = combs.next()
+			if idxs == nil {
+				break
+			}
+
+			for _, idx := range idxs {
+				extras = append(extras, Cell{Pos: pos, Value: nums[idx]})
+				for _, h := range hs {
+					counts[h][nums[idx]]--
+				}
+			}
+
+			if visit(k + 1) {
+				return true
+			}
+
+			for _, idx := range idxs {
+				extras = extras[:len(extras)-1]
+				for _, h := range hs {
+					counts[h][nums[idx]]++
+				}
+			}
+		}
+
+		return false
+	}
+
+	if !visit(0) {
+		return none
+	}
+
+	pattern := s.Candidates.Filter(func(c Cell) bool {
+		return c.Pos.sees(extras[0].Pos)
+	})
+
+	if len(extras) == 1 {
+		return finderResult{Solved: extras, Eliminated: nil, Pattern: pattern}
+	}
+
+	var eliminated CellList
+
+	switch {
+	case extras.All(func(c Cell) bool { return c.Value == extras[0].Value }):
+		eliminated = s.Candidates.Filter(func(c Cell) bool {
+			return c.Value == extras[0].Value && extras.All(func(e Cell) bool {
+				return c.Pos != e.Pos && c.Pos.sees(e.Pos)
+			})
+		})
+	case extras.All(func(c Cell) bool { return c.Pos == extras[0].Pos }):
+		eliminated = s.Candidates.Filter(func(c Cell) bool {
+			return c.Pos == extras[0].Pos && !extras.Any(func(e Cell) bool {
+				return e.Value == c.Value
+			})
+		})
+	}
+
+	if len(eliminated) == 0 {
+		return none
+	}
+
+	return finderResult{Solved: nil, Eliminated: eliminated, Pattern: uniqueCells(extras)}
+}
+
+func (s *Sudoku) findBUG1() finderResult {
+	return s.findBUG(1)
+}
+
+func (s *Sudoku) findBUGN() finderResult {
+	return s.findBUG(2 * bugMaxCells)
+}
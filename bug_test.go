@@ -0,0 +1,86 @@
+package sudoku_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+// bugState solves the grid up to the BUG+1 state, where r9c9 (126) is the
+// only cell with more than two candidates
+func bugState(t *testing.T) *sudoku.Sudoku {
+	grid := "300409100500030000179000004000000500000085003605100700000000070000706900084900000"
+
+	// The strategies before BUG in solver order, later ones may solve it
+	names := []string{}
+	for _, name := range sudoku.AllStrategies() {
+		if name == "bug+1" {
+			break
+		}
+		names = append(names, name)
+	}
+
+	s, err := sudoku.NewSudoku(grid)
+	assert.NilError(t, err)
+
+	res, err := s.SolveContext(context.Background(), sudoku.SolveOptions{Strategies: names})
+	assert.NilError(t, err)
+	assert.Equal(t, sudoku.StatusStalled, res.Status)
+
+	return s
+}
+
+func TestBUG1(t *testing.T) {
+	s := bugState(t)
+
+	for _, name := range []string{"bug+1", "bug+n"} {
+		step, ok, err := s.HintWith([]string{name})
+		assert.NilError(t, err)
+		assert.Assert(t, ok)
+		assert.DeepEqual(t, sudoku.CellList{cell(9, 9, 9, 2)}, step.Solved)
+	}
+
+	res, err := s.SolveContext(context.Background(),
+		sudoku.SolveOptions{Strategies: []string{"singles (simple)", "singles", "bug+1"}})
+	assert.NilError(t, err)
+	assert.Equal(t, sudoku.StatusSolved, res.Status)
+
+	solution, ok := s.Solution()
+	assert.Assert(t, ok)
+	assert.Equal(t, solution, s.GetGridString())
+}
+
+func TestBUGN(t *testing.T) {
+	s := bugState(t)
+
+	// Put back 2 to r4c9, now r4c9 or r9c9 is 2
+	fresh, err := sudoku.NewSudoku(s.GetGridString())
+	assert.NilError(t, err)
+
+	extra := cell(4, 9, 6, 2)
+	cands := map[sudoku.Cell]bool{extra: true}
+	for _, c := range s.Candidates {
+		cands[c] = true
+	}
+	s.Candidates = fresh.Candidates.Filter(func(c sudoku.Cell) bool {
+		return cands[c]
+	})
+
+	_, ok, err := s.HintWith([]string{"bug+1"})
+	assert.NilError(t, err)
+	assert.Assert(t, !ok)
+
+	step, ok, err := s.HintWith([]string{"bug+n"})
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, sudoku.CellList{cell(6, 9, 6, 2), cell(7, 9, 9, 2)}, step.Eliminated)
+	assert.DeepEqual(t, sudoku.CellList{extra, cell(9, 9, 9, 2)}, step.Pattern)
+
+	assert.NilError(t, s.ApplyStep(step))
+
+	solution, ok := s.Solution()
+	assert.Assert(t, ok)
+	assertSound(t, s, solution)
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+// Permutations of three elements
+var perms3 = [6][3]int{
+	{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0},
+}
+
+// Search state for the minimal lexicographic form of a grid over the
+// transformations that keep it valid: transposing, permuting bands and
+// stacks, permuting rows and columns within them and relabeling numbers.
+type minlex struct {
+	grid    [sudokuGridSize]int8
+	columns [sudokuNumbers]int
+
+	best    [sudokuGridSize]int8
+	hasBest bool
+
+	out      [sudokuGridSize]int8
+	rowUsed  [sudokuNumbers]bool
+	bandUsed [sudokuBoxes]bool
+	band     int
+
+	// Number of times best has been replaced
+	updates int
+}
+
+// Canonical returns the minimal lexicographic form of the grid, empty
+// cells sort first. Grids that are equivalent by the validity preserving
+// transformations have the same canonical form.
+func Canonical(grid string) (string, error) {
+	s, err := NewSudoku(grid)
+	if err != nil {
+		return "", err
+	}
+
+	return valuesString(canonicalValues(s.gridValues())), nil
+}
+
+func canonicalValues(values [sudokuGridSize]int8) [sudokuGridSize]int8 {
+	m := minlex{}
+
+	for t := 0; t < 2; t++ {
+		for i := 0; i < sudokuGridSize; i++ {
+			row, col := i/sudokuNumbers, i%sudokuNumbers
+			if t == 0 {
+				m.grid[i] = values[i]
+			} else {
+				m.grid[i] = values[col*sudokuNumbers+row]
+			}
+		}
+
+		for _, stacks := range perms3 {
+			for _, c0 := range perms3 {
+				for _, c1 := range perms3 {
+					for _, c2 := range perms3 {
+						cperms := [3][3]int{c0, c1, c2}
+
+						for j := 0; j < sudokuNumbers; j++ {
+							stack := stacks[j/sudokuBoxes]
+							m.columns[j] = stack*sudokuBoxes + cperms[j/sudokuBoxes][j%sudokuBoxes]
+						}
+
+						m.search(0, [sudokuNumbers + 1]int8{}, 1, false)
+					}
+				}
+			}
+		}
+	}
+
+	return m.best
+}
+
+// search fills the rows from depth on. less tells that the rows before
+// depth are smaller than in best; it holds only until best is replaced,
+// after that best has the same rows before depth.
+func (m *minlex) search(depth int, mapping [sudokuNumbers + 1]int8, next int8, less bool) {
+	if depth == sudokuNumbers {
+		if less || !m.hasBest {
+			m.best = m.out
+			m.hasBest = true
+			m.updates++
+		}
+		return
+	}
+
+	updates := m.updates
+
+	var rows []int
+	if depth%sudokuBoxes == 0 {
+		for band := 0; band < sudokuBoxes; band++ {
+			if !m.bandUsed[band] {
+				for r := 0; r < sudokuBoxes; r++ {
+					rows = append(rows, band*sudokuBoxes+r)
+				}
+			}
+		}
+	} else {
+		for r := 0; r < sudokuBoxes; r++ {
+			row := m.band*sudokuBoxes + r
+			if !m.rowUsed[row] {
+				rows = append(rows, row)
+			}
+		}
+	}
+
+	prevBand := m.band
+
+	for _, row := range rows {
+		rmapping := mapping
+		rnext := next
+		rless := less && m.updates == updates
+		pruned := false
+
+		offset := depth * sudokuNumbers
+
+		for j := 0; j < sudokuNumbers; j++ {
+			v := m.grid[row*sudokuNumbers+m.columns[j]]
+			if v != 0 {
+				if rmapping[v] == 0 {
+					rmapping[v] = rnext
+					rnext++
+				}
+				v = rmapping[v]
+			}
+			m.out[offset+j] = v
+
+			if !rless && m.hasBest {
+				if v > m.best[offset+j] {
+					pruned = true
+					break
+				} else if v < m.best[offset+j] {
+					rless = true
+				}
+			}
+		}
+
+		if pruned {
+			continue
+		}
+
+		band := row / sudokuBoxes
+		m.rowUsed[row] = true
+		if depth%sudokuBoxes == 0 {
+			m.bandUsed[band] = true
+			m.band = band
+		}
+
+		m.search(depth+1, rmapping, rnext, rless)
+
+		m.rowUsed[row] = false
+		if depth%sudokuBoxes == 0 {
+			m.bandUsed[band] = false
+		}
+		m.band = prevBand
+	}
+}
@@ -17,7 +17,8 @@
 package sudoku
 
 import (
-	_ "fmt"
+	"encoding/json"
+	"fmt"
 	"sort"
 )
 
@@ -35,6 +36,33 @@ type cellGetter func(x int8) CellList
 type cellFinder func(cells CellList) finderResult
 type cellPredicate func(Cell) bool
 
+type cellJSON struct {
+	Row    int8 `json:"row"`
+	Column int8 `json:"column"`
+	Value  int8 `json:"value"`
+}
+
+func (c Cell) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cellJSON{Row: c.Pos.Row, Column: c.Pos.Column, Value: c.Value})
+}
+
+func (c *Cell) UnmarshalJSON(data []byte) error {
+	var cj cellJSON
+
+	if err := json.Unmarshal(data, &cj); err != nil {
+		return err
+	}
+
+	if cj.Row < 1 || cj.Row > sudokuNumbers || cj.Column < 1 || cj.Column > sudokuNumbers ||
+		cj.Value < 0 || cj.Value > sudokuNumbers {
+		return fmt.Errorf("Invalid cell (%d, %d) = %d", cj.Row, cj.Column, cj.Value)
+	}
+
+	*c = Cell{}.init(cj.Row, cj.Column, cj.Value)
+
+	return nil
+}
+
 func getCellNumbers(pos Pos, cands CellList) cellNumbers {
 	nums := cands.FilterMapInt8(
 		func(c Cell) int8 { return c.Value },
@@ -158,6 +186,42 @@ func uniqueNumbers(cells CellList) []int8 {
 	return res
 }
 
+func copyCells(cells CellList) CellList {
+	if cells == nil {
+		return nil
+	}
+
+	res := make(CellList, len(cells))
+	copy(res, cells)
+
+	return res
+}
+
+func equalCells(a, b CellList) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// candidateMasks returns a bit mask of the candidate numbers of each cell
+func candidateMasks(cells CellList) [sudokuGridSize]uint16 {
+	var masks [sudokuGridSize]uint16
+
+	for _, c := range cells {
+		masks[c.Pos.index()] |= 1 << uint(c.Value)
+	}
+
+	return masks
+}
+
 func uniqueCells(cells CellList) CellList {
 	sortCells(cells)
 	res := dedupeCells(cells)
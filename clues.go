@@ -0,0 +1,213 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"fmt"
+)
+
+// Symmetry is the symmetry class of the clue pattern of a puzzle, by the
+// rotations and reflections of the grid that keep the pattern.
+type Symmetry int
+
+const (
+	// SymmetryNone means that no rotation or reflection keeps the pattern.
+	SymmetryNone Symmetry = iota
+	// SymmetryRotational means a half turn around the center.
+	SymmetryRotational
+	// SymmetryVertical means a reflection across the middle column.
+	SymmetryVertical
+	// SymmetryHorizontal means a reflection across the middle row.
+	SymmetryHorizontal
+	// SymmetryDiagonal means a reflection across the main diagonal.
+	SymmetryDiagonal
+	// SymmetryAntiDiagonal means a reflection across the anti-diagonal.
+	SymmetryAntiDiagonal
+	// SymmetryOrthogonal means reflections across both the middle row and
+	// column.
+	SymmetryOrthogonal
+	// SymmetryBiDiagonal means reflections across both diagonals.
+	SymmetryBiDiagonal
+	// SymmetryQuarterTurn means a quarter turn around the center.
+	SymmetryQuarterTurn
+	// SymmetryFull means all the rotations and reflections.
+	SymmetryFull
+)
+
+var symmetryNames = []string{
+	"none", "rotational", "vertical", "horizontal", "diagonal",
+	"anti-diagonal", "orthogonal", "bi-diagonal", "quarter-turn", "full",
+}
+
+func (sy Symmetry) String() string {
+	if sy < 0 || int(sy) >= len(symmetryNames) {
+		return fmt.Sprintf("Symmetry(%d)", int(sy))
+	}
+	return symmetryNames[sy]
+}
+
+func (sy Symmetry) MarshalText() ([]byte, error) {
+	return []byte(sy.String()), nil
+}
+
+func (sy *Symmetry) UnmarshalText(text []byte) error {
+	for i, name := range symmetryNames {
+		if name == string(text) {
+			*sy = Symmetry(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Invalid symmetry '%s'", text)
+}
+
+// Clue tells if a given can be removed without losing uniqueness.
+type Clue struct {
+	Cell      Cell `json:"cell"`
+	Redundant bool `json:"redundant"`
+}
+
+// ClueAnalysis describes the givens of a puzzle.
+type ClueAnalysis struct {
+	// The givens in grid order
+	Clues []Clue `json:"clues"`
+	// No given is redundant
+	Minimal  bool     `json:"minimal"`
+	Symmetry Symmetry `json:"symmetry"`
+	// Givens that can be removed together keeping the solution unique,
+	// no other given can be removed after them
+	Removable CellList `json:"removable,omitempty"`
+}
+
+const lastLine = sudokuNumbers - 1
+
+// Rotations and reflections of a cell by row and column from 0 to 8
+var symmetryTransforms = [...]func(r, c int) (int, int){
+	func(r, c int) (int, int) { return lastLine - r, lastLine - c },
+	func(r, c int) (int, int) { return c, lastLine - r },
+	func(r, c int) (int, int) { return r, lastLine - c },
+	func(r, c int) (int, int) { return lastLine - r, c },
+	func(r, c int) (int, int) { return c, r },
+	func(r, c int) (int, int) { return lastLine - c, lastLine - r },
+}
+
+// Indexes of symmetryTransforms
+const (
+	halfTurn = iota
+	quarterTurn
+	vertical
+	horizontal
+	diagonal
+	antiDiagonal
+)
+
+func patternSymmetry(values [sudokuGridSize]int8) Symmetry {
+	var kept [len(symmetryTransforms)]bool
+
+	for k, fun := range symmetryTransforms {
+		kept[k] = true
+
+		for i, v := range values {
+			r, c := fun(i/sudokuNumbers, i%sudokuNumbers)
+			if (v == 0) != (values[r*sudokuNumbers+c] == 0) {
+				kept[k] = false
+				break
+			}
+		}
+	}
+
+	switch {
+	case kept[quarterTurn] && kept[vertical]:
+		return SymmetryFull
+	case kept[quarterTurn]:
+		return SymmetryQuarterTurn
+	case kept[vertical] && kept[horizontal]:
+		return SymmetryOrthogonal
+	case kept[diagonal] && kept[antiDiagonal]:
+		return SymmetryBiDiagonal
+	case kept[vertical]:
+		return SymmetryVertical
+	case kept[horizontal]:
+		return SymmetryHorizontal
+	case kept[diagonal]:
+		return SymmetryDiagonal
+	case kept[antiDiagonal]:
+		return SymmetryAntiDiagonal
+	case kept[halfTurn]:
+		return SymmetryRotational
+	}
+
+	return SymmetryNone
+}
+
+func uniqueSolution(values [sudokuGridSize]int8) bool {
+	return len(bruteForceSolutions(values, 2)) == 1
+}
+
+// AnalyzeClues tells which givens of a puzzle are redundant, whether the
+// puzzle is minimal and the symmetry class of its clue pattern. The
+// puzzle must have a unique solution.
+func AnalyzeClues(grid string) (ClueAnalysis, error) {
+	res := ClueAnalysis{Clues: []Clue{}}
+
+	s, err := NewSudoku(grid)
+	if err != nil {
+		return res, err
+	}
+
+	values := s.givenValues()
+	if !uniqueSolution(values) {
+		return res, ErrNotUnique
+	}
+
+	res.Symmetry = patternSymmetry(values)
+	res.Minimal = true
+
+	for i, cell := range s.Solved {
+		if !s.givens[i] {
+			continue
+		}
+
+		values[i] = 0
+		redundant := uniqueSolution(values)
+		values[i] = cell.Value
+
+		res.Clues = append(res.Clues, Clue{Cell: cell, Redundant: redundant})
+		if redundant {
+			res.Minimal = false
+		}
+	}
+
+	// Removing a clue never helps uniqueness, so a clue that cannot be
+	// removed now cannot be removed later either
+	for _, clue := range res.Clues {
+		if !clue.Redundant {
+			continue
+		}
+
+		idx := clue.Cell.Pos.index()
+		values[idx] = 0
+
+		if uniqueSolution(values) {
+			res.Removable = append(res.Removable, clue.Cell)
+		} else {
+			values[idx] = clue.Cell.Value
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,121 @@
+package sudoku_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+const solvedGrid = "765921483319548267248367519182453976437619825596782341653274198921836754874195632"
+
+// blank empties the cells, given as row and column pairs, of the grid
+func blank(grid string, cells ...int) string {
+	runes := []rune(grid)
+	for i := 0; i < len(cells); i += 2 {
+		runes[(cells[i]-1)*9+cells[i+1]-1] = '0'
+	}
+
+	return string(runes)
+}
+
+func TestAnalyzeClues(t *testing.T) {
+	res, err := sudoku.AnalyzeClues("000921003009000060000000500080403006007000800500700040003000000020000700800195000")
+	assert.NilError(t, err)
+	assert.Equal(t, 23, len(res.Clues))
+	assert.DeepEqual(t, sudoku.Clue{Cell: cell(1, 4, 2, 9)}, res.Clues[0])
+	assert.Assert(t, res.Minimal)
+	assert.Equal(t, sudoku.SymmetryNone, res.Symmetry)
+	assert.Equal(t, 0, len(res.Removable))
+
+	// Generated with clues removed in symmetric pairs
+	grid := "000000610002008073060070809000029000007413900000780000703040050650300100018000000"
+	res, err = sudoku.AnalyzeClues(grid)
+	assert.NilError(t, err)
+	assert.Assert(t, !res.Minimal)
+	assert.Equal(t, sudoku.SymmetryRotational, res.Symmetry)
+
+	redundant := 0
+	for _, clue := range res.Clues {
+		if clue.Redundant {
+			redundant++
+		}
+	}
+	assert.Equal(t, 5, redundant)
+	assertMinimal(t, grid, res.Removable)
+}
+
+// assertMinimal checks that the grid without the removable clues is
+// minimal
+func assertMinimal(t *testing.T, grid string, removable sudoku.CellList) {
+	t.Helper()
+
+	cells := []int{}
+	for _, c := range removable {
+		cells = append(cells, int(c.Pos.Row), int(c.Pos.Column))
+	}
+
+	res, err := sudoku.AnalyzeClues(blank(grid, cells...))
+	assert.NilError(t, err)
+	assert.Assert(t, res.Minimal)
+}
+
+func TestAnalyzeCluesSolved(t *testing.T) {
+	res, err := sudoku.AnalyzeClues(solvedGrid)
+	assert.NilError(t, err)
+	assert.Equal(t, 81, len(res.Clues))
+	assert.Assert(t, !res.Minimal)
+	assert.Equal(t, sudoku.SymmetryFull, res.Symmetry)
+
+	for _, clue := range res.Clues {
+		assert.Assert(t, clue.Redundant)
+	}
+
+	assertMinimal(t, solvedGrid, res.Removable)
+}
+
+func TestClueSymmetry(t *testing.T) {
+	tests := []struct {
+		cells    []int
+		symmetry sudoku.Symmetry
+	}{
+		{[]int{1, 2}, sudoku.SymmetryNone},
+		{[]int{1, 2, 9, 8}, sudoku.SymmetryRotational},
+		{[]int{1, 5}, sudoku.SymmetryVertical},
+		{[]int{5, 1}, sudoku.SymmetryHorizontal},
+		{[]int{1, 1}, sudoku.SymmetryDiagonal},
+		{[]int{1, 9}, sudoku.SymmetryAntiDiagonal},
+		{[]int{1, 5, 9, 5}, sudoku.SymmetryOrthogonal},
+		{[]int{1, 1, 9, 9}, sudoku.SymmetryBiDiagonal},
+		{[]int{1, 2, 2, 9, 9, 8, 8, 1}, sudoku.SymmetryQuarterTurn},
+		{[]int{5, 5}, sudoku.SymmetryFull},
+	}
+
+	for _, test := range tests {
+		res, err := sudoku.AnalyzeClues(blank(solvedGrid, test.cells...))
+		assert.NilError(t, err)
+		assert.Equal(t, test.symmetry, res.Symmetry, test.cells)
+	}
+}
+
+func TestSymmetryJSON(t *testing.T) {
+	data, err := json.Marshal(sudoku.SymmetryAntiDiagonal)
+	assert.NilError(t, err)
+	assert.Equal(t, `"anti-diagonal"`, string(data))
+
+	var sy sudoku.Symmetry
+	assert.NilError(t, json.Unmarshal([]byte(`"quarter-turn"`), &sy))
+	assert.Equal(t, sudoku.SymmetryQuarterTurn, sy)
+
+	assert.ErrorContains(t, json.Unmarshal([]byte(`"spiral"`), &sy), "Invalid symmetry")
+	assert.Equal(t, "Symmetry(42)", sudoku.Symmetry(42).String())
+}
+
+func TestAnalyzeCluesErrors(t *testing.T) {
+	_, err := sudoku.AnalyzeClues("000000000000000000000000000000000000000000000000000000000000000000000000000000000")
+	assert.Equal(t, sudoku.ErrNotUnique, err)
+
+	_, err = sudoku.AnalyzeClues("123")
+	assert.Assert(t, err != nil)
+}
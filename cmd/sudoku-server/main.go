@@ -0,0 +1,46 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+// Command sudoku-server serves the JSON API of package server.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/jjhoo/go-sudoku/server"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	timeout := flag.Duration("timeout", 10*time.Second, "time limit per request")
+	maxBody := flag.Int64("max-body", 64<<10, "maximum request body size in bytes")
+	flag.Parse()
+
+	h := server.New(server.Options{MaxBodyBytes: *maxBody, Timeout: *timeout})
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           h,
+		ReadHeaderTimeout: 5 * time.Second,
+		WriteTimeout:      *timeout + 5*time.Second,
+	}
+
+	log.Printf("Listening on %s", *addr)
+	log.Fatal(srv.ListenAndServe())
+}
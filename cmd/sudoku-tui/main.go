@@ -0,0 +1,272 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+// Command sudoku-tui is a terminal user interface for playing sudoku.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jjhoo/go-sudoku"
+)
+
+const (
+	ansiReset    = "\x1b[0m"
+	ansiBold     = "\x1b[1m"
+	ansiReverse  = "\x1b[7m"
+	ansiRed      = "\x1b[31m"
+	ansiCyan     = "\x1b[36m"
+	ansiPattern  = "\x1b[43m"
+	ansiAffected = "\x1b[41m"
+	ansiPlaced   = "\x1b[42m"
+	clearScreen  = "\x1b[2J\x1b[H"
+)
+
+const help = "arrows move, 1-9 enter, 0/x erase, p pencil, u undo, r redo, c check, m mistakes, h hint, a apply hint, q quit"
+
+func main() {
+	seed := flag.Int64("seed", 0, "seed for generating a puzzle, 0 for random")
+	flag.Parse()
+
+	var grid string
+	if flag.NArg() > 0 {
+		grid = strings.Replace(flag.Arg(0), ".", "0", -1)
+	} else {
+		var err error
+		grid, err = sudoku.Generate(sudoku.GenerateOptions{Seed: *seed, Symmetric: true, Solvable: true})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	m, err := newModel(grid)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fd := int(os.Stdin.Fd())
+	state, err := makeRaw(fd)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer restore(fd, state)
+
+	run(m)
+}
+
+func run(m *model) {
+	in := bufio.NewReader(os.Stdin)
+	out := bufio.NewWriter(os.Stdout)
+
+	for {
+		draw(out, m)
+		out.Flush()
+
+		key, err := readKey(in)
+		if err != nil {
+			return
+		}
+
+		m.message = ""
+
+		switch key {
+		case "q", "\x03":
+			out.WriteString(clearScreen)
+			out.Flush()
+			return
+		case "up":
+			m.moveCursor(-1, 0)
+		case "down":
+			m.moveCursor(1, 0)
+		case "left":
+			m.moveCursor(0, -1)
+		case "right":
+			m.moveCursor(0, 1)
+		case "0", "x", " ", "\x7f":
+			m.erase()
+		case "p":
+			m.pencil = !m.pencil
+		case "u":
+			m.undo()
+		case "r":
+			m.redo()
+		case "c":
+			m.checkConflicts()
+		case "m":
+			m.checkMistakes()
+		case "h":
+			m.requestHint()
+		case "a":
+			m.applyHint()
+		default:
+			if len(key) == 1 && key[0] >= '1' && key[0] <= '9' {
+				m.enter(int8(key[0] - '0'))
+			}
+		}
+
+		if m.game.Solved() {
+			m.message = "Solved!"
+		}
+	}
+}
+
+func readKey(in *bufio.Reader) (string, error) {
+	b, err := in.ReadByte()
+	if err != nil {
+		return "", err
+	}
+
+	if b != 0x1b {
+		return string(b), nil
+	}
+
+	// Arrow keys are sent as ESC [ A..D
+	if in.Buffered() < 2 {
+		return "esc", nil
+	}
+
+	b1, _ := in.ReadByte()
+	b2, _ := in.ReadByte()
+
+	if b1 == '[' {
+		switch b2 {
+		case 'A':
+			return "up", nil
+		case 'B':
+			return "down", nil
+		case 'C':
+			return "right", nil
+		case 'D':
+			return "left", nil
+		}
+	}
+
+	return "esc", nil
+}
+
+func hintHighlights(m *model) (pattern, affected, placed [gridSize]bool) {
+	if m.hint == nil {
+		return
+	}
+
+	for _, c := range m.hint.Pattern {
+		pattern[cellIndex(c.Pos)] = true
+	}
+	for _, c := range m.hint.Eliminated {
+		affected[cellIndex(c.Pos)] = true
+	}
+	for _, c := range m.hint.Solved {
+		placed[cellIndex(c.Pos)] = true
+	}
+
+	return
+}
+
+func draw(out *bufio.Writer, m *model) {
+	conflicts := m.conflicts()
+	pattern, affected, placed := hintHighlights(m)
+
+	border := "+-------------+-------------+-------------+\r\n"
+
+	out.WriteString(clearScreen)
+	out.WriteString(border)
+
+	for row := 0; row < 9; row++ {
+		for sub := 0; sub < 3; sub++ {
+			for col := 0; col < 9; col++ {
+				if col%3 == 0 {
+					out.WriteString("| ")
+				}
+
+				idx := row*9 + col
+				out.WriteString(cellStyle(m, idx, conflicts, pattern, affected, placed))
+				out.WriteString(cellText(m, idx, sub))
+				out.WriteString(ansiReset + " ")
+			}
+			out.WriteString("|\r\n")
+		}
+
+		if row%3 == 2 {
+			out.WriteString(border)
+		}
+	}
+
+	mode := "values"
+	if m.pencil {
+		mode = "pencil marks"
+	}
+
+	fmt.Fprintf(out, "Cell %s, entering %s\r\n", cellName(m.cursor), mode)
+	fmt.Fprintf(out, "%s\r\n", m.message)
+	fmt.Fprintf(out, "%s\r\n", help)
+}
+
+func cellStyle(m *model, idx int, conflicts, pattern, affected, placed [gridSize]bool) string {
+	style := ""
+
+	switch {
+	case placed[idx]:
+		style += ansiPlaced
+	case affected[idx]:
+		style += ansiAffected
+	case pattern[idx]:
+		style += ansiPattern
+	}
+
+	switch {
+	case conflicts[idx]:
+		style += ansiRed
+	case m.given(idx):
+		style += ansiBold
+	case m.value(idx) != 0:
+		style += ansiCyan
+	}
+
+	if idx == m.cursor {
+		style += ansiReverse
+	}
+
+	return style
+}
+
+func cellText(m *model, idx, sub int) string {
+	if v := m.value(idx); v != 0 {
+		if sub == 1 {
+			return fmt.Sprintf(" %d ", v)
+		}
+		return "   "
+	}
+
+	var b strings.Builder
+	marks := m.marks(idx)
+	for k := 1; k <= 3; k++ {
+		n := sub*3 + k
+		if marks&(1<<uint(n)) != 0 {
+			b.WriteByte(byte('0' + n))
+		} else {
+			b.WriteByte(' ')
+		}
+	}
+
+	return b.String()
+}
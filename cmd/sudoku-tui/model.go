@@ -0,0 +1,201 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jjhoo/go-sudoku"
+)
+
+const gridSize = 81
+
+type model struct {
+	game *sudoku.Game
+
+	cursor int
+	pencil bool
+
+	hint    *sudoku.Step
+	message string
+}
+
+func newModel(grid string) (*model, error) {
+	game, err := sudoku.NewGame(grid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model{game: game}, nil
+}
+
+func cellIndex(pos sudoku.Pos) int {
+	return int(pos.Row-1)*9 + int(pos.Column-1)
+}
+
+func cellName(idx int) string {
+	return fmt.Sprintf("r%dc%d", idx/9+1, idx%9+1)
+}
+
+func (m *model) rowCol(idx int) (int8, int8) {
+	return int8(idx/9 + 1), int8(idx%9 + 1)
+}
+
+func (m *model) value(idx int) int8 {
+	row, col := m.rowCol(idx)
+	return m.game.Value(row, col)
+}
+
+func (m *model) given(idx int) bool {
+	row, col := m.rowCol(idx)
+	return m.game.Given(row, col)
+}
+
+func (m *model) marks(idx int) uint16 {
+	row, col := m.rowCol(idx)
+
+	var mask uint16
+	for _, n := range m.game.Marks(row, col) {
+		mask |= 1 << uint(n)
+	}
+	return mask
+}
+
+func (m *model) moveCursor(drow, dcol int) {
+	row := (m.cursor/9 + drow + 9) % 9
+	col := (m.cursor%9 + dcol + 9) % 9
+	m.cursor = row*9 + col
+}
+
+func (m *model) report(err error) {
+	if err != nil {
+		m.message = err.Error()
+	}
+}
+
+func (m *model) enter(n int8) {
+	row, col := m.rowCol(m.cursor)
+	m.hint = nil
+
+	if m.pencil {
+		m.report(m.game.ToggleMark(row, col, n))
+	} else {
+		m.report(m.game.SetValue(row, col, n))
+	}
+}
+
+func (m *model) erase() {
+	row, col := m.rowCol(m.cursor)
+	m.hint = nil
+	m.report(m.game.Erase(row, col))
+}
+
+func (m *model) undo() {
+	m.hint = nil
+	if !m.game.Undo() {
+		m.message = "Nothing to undo"
+	}
+}
+
+func (m *model) redo() {
+	m.hint = nil
+	if !m.game.Redo() {
+		m.message = "Nothing to redo"
+	}
+}
+
+func (m *model) conflicts() [gridSize]bool {
+	var res [gridSize]bool
+
+	for _, c := range m.game.Conflicts() {
+		res[cellIndex(c.Pos)] = true
+	}
+
+	return res
+}
+
+func (m *model) checkConflicts() bool {
+	conflicts := m.game.Conflicts()
+	if len(conflicts) > 0 {
+		m.message = fmt.Sprintf("Conflict at %s", describeCells(conflicts))
+		return true
+	}
+
+	m.message = "No conflicts"
+	return false
+}
+
+func (m *model) checkMistakes() {
+	mistakes := m.game.Mistakes()
+	if len(mistakes) > 0 {
+		m.message = fmt.Sprintf("Mistakes at %s", describeCells(mistakes))
+		return
+	}
+
+	m.message = "No mistakes"
+}
+
+func describeCells(cells sudoku.CellList) string {
+	parts := []string{}
+	for _, c := range cells {
+		parts = append(parts, fmt.Sprintf("%s=%d", cellName(cellIndex(c.Pos)), c.Value))
+	}
+	return strings.Join(parts, " ")
+}
+
+func (m *model) requestHint() {
+	m.hint = nil
+
+	if m.checkConflicts() {
+		return
+	}
+
+	step, ok, err := m.game.Hint()
+	if err != nil {
+		m.message = fmt.Sprintf("The pencil marks are inconsistent: %v", err)
+		return
+	}
+
+	if !ok {
+		m.message = "No hint available"
+		return
+	}
+
+	m.hint = &step
+
+	if len(step.Solved) > 0 {
+		m.message = fmt.Sprintf("%s: place %s", step.Strategy, describeCells(step.Solved))
+	} else {
+		m.message = fmt.Sprintf("%s: eliminate %s", step.Strategy, describeCells(step.Eliminated))
+	}
+}
+
+func (m *model) applyHint() {
+	if m.hint == nil {
+		m.message = "No hint to apply"
+		return
+	}
+
+	if err := m.game.ApplyHint(*m.hint); err != nil {
+		m.message = err.Error()
+		return
+	}
+
+	m.hint = nil
+	m.message = "Hint applied"
+}
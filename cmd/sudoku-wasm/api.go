@@ -0,0 +1,102 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jjhoo/go-sudoku/server"
+)
+
+const callTimeout = 10 * time.Second
+
+type apiFunc func(ctx context.Context, input []byte) (interface{}, error)
+
+func decode(input []byte, v interface{}) error {
+	if err := json.Unmarshal(input, v); err != nil {
+		return &server.Error{Code: "invalid_json", Message: err.Error()}
+	}
+	return nil
+}
+
+// The functions exported to JavaScript, they share the request and
+// response types of the HTTP API.
+var apiFuncs = map[string]apiFunc{
+	"solve": func(ctx context.Context, input []byte) (interface{}, error) {
+		var req server.SolveRequest
+		if err := decode(input, &req); err != nil {
+			return nil, err
+		}
+		return server.Solve(ctx, req)
+	},
+	"hint": func(ctx context.Context, input []byte) (interface{}, error) {
+		var req server.GridRequest
+		if err := decode(input, &req); err != nil {
+			return nil, err
+		}
+		return server.Hint(ctx, req)
+	},
+	"rate": func(ctx context.Context, input []byte) (interface{}, error) {
+		var req server.GridRequest
+		if err := decode(input, &req); err != nil {
+			return nil, err
+		}
+		return server.Rate(ctx, req)
+	},
+	"generate": func(ctx context.Context, input []byte) (interface{}, error) {
+		var req server.GenerateRequest
+		if err := decode(input, &req); err != nil {
+			return nil, err
+		}
+		return server.Generate(ctx, req)
+	},
+}
+
+// call runs the named function with a JSON request and returns a JSON
+// response, or {"error": {...}} on failure.
+func call(name string, input string) string {
+	fun, ok := apiFuncs[name]
+	if !ok {
+		return errorJSON(&server.Error{Code: "unknown_function", Message: fmt.Sprintf("Unknown function '%s'", name)})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+
+	resp, err := fun(ctx, []byte(input))
+	if err != nil {
+		return errorJSON(server.ErrorFrom(err))
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return errorJSON(server.ErrorFrom(err))
+	}
+
+	return string(data)
+}
+
+func errorJSON(e *server.Error) string {
+	data, _ := json.Marshal(struct {
+		Error *server.Error `json:"error"`
+	}{e})
+
+	return string(data)
+}
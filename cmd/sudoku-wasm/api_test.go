@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func decodeResponse(t *testing.T, out string) map[string]interface{} {
+	var resp map[string]interface{}
+	assert.NilError(t, json.Unmarshal([]byte(out), &resp), out)
+	return resp
+}
+
+func TestCall(t *testing.T) {
+	resp := decodeResponse(t, call("solve",
+		`{"grid": "000040700500780020070002006810007900460000051009600078900800010080064009002050000"}`))
+	assert.Equal(t, "solved", resp["status"])
+	assert.Equal(t, "628341795594786123173592846815437962467928351239615478956873214781264539342159687", resp["grid"])
+
+	resp = decodeResponse(t, call("hint",
+		`{"grid": "000040700500780020070002006810007900460000051009600078900800010080064009002050000"}`))
+	assert.Equal(t, true, resp["found"])
+
+	resp = decodeResponse(t, call("rate",
+		`{"grid": "000704005020010070000080002090006250600070008053200010400090000030060090200407000"}`))
+	assert.Equal(t, "solved", resp["status"])
+
+	resp = decodeResponse(t, call("generate", `{"seed": 3}`))
+	assert.Equal(t, 81, len(resp["grid"].(string)))
+}
+
+func TestCallErrors(t *testing.T) {
+	resp := decodeResponse(t, call("solve", `{"grid": "123"}`))
+	e := resp["error"].(map[string]interface{})
+	assert.Equal(t, "invalid_grid", e["code"])
+	assert.Equal(t, "Grid has invalid size '3'", e["message"])
+
+	resp = decodeResponse(t, call("solve", `not json`))
+	assert.Equal(t, "invalid_json", resp["error"].(map[string]interface{})["code"])
+
+	resp = decodeResponse(t, call("guess", `{}`))
+	assert.Equal(t, "unknown_function", resp["error"].(map[string]interface{})["code"])
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+//go:build js && wasm
+// +build js,wasm
+
+// Command sudoku-wasm exposes the solver to JavaScript as the global
+// object sudoku with the functions solve, hint, rate and generate. Each
+// takes a JSON string, or an object, and returns a JSON string.
+package main
+
+import (
+	"syscall/js"
+)
+
+func register() {
+	obj := js.Global().Get("Object").New()
+
+	for name := range apiFuncs {
+		name := name
+		obj.Set(name, js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			input := "{}"
+			if len(args) > 0 {
+				if args[0].Type() == js.TypeString {
+					input = args[0].String()
+				} else {
+					input = js.Global().Get("JSON").Call("stringify", args[0]).String()
+				}
+			}
+			return call(name, input)
+		}))
+	}
+
+	js.Global().Set("sudoku", obj)
+}
+
+func main() {
+	register()
+
+	if ready := js.Global().Get("onSudokuReady"); ready.Type() == js.TypeFunction {
+		ready.Invoke()
+	}
+
+	select {}
+}
@@ -0,0 +1,28 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"syscall/js"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRegister(t *testing.T) {
+	register()
+
+	obj := js.Global().Get("sudoku")
+
+	out := obj.Call("solve", `{"grid": "000040700500780020070002006810007900460000051009600078900800010080064009002050000"}`)
+	resp := decodeResponse(t, out.String())
+	assert.Equal(t, "solved", resp["status"])
+
+	// Objects are accepted as well as JSON strings
+	req := js.Global().Get("Object").New()
+	req.Set("grid", "000704005020010070000080002090006250600070008053200010400090000030060090200407000")
+
+	resp = decodeResponse(t, obj.Call("rate", req).String())
+	assert.Equal(t, "solved", resp["status"])
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+// Command sudoku solves a single grid given as an argument, or with
+// -batch a file of puzzles, one per line.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jjhoo/go-sudoku"
+)
+
+func main() {
+	batch := flag.Bool("batch", false, "solve puzzles read from the file given as argument, or stdin")
+	workers := flag.Int("workers", 0, "number of concurrent workers in batch mode, 0 for one per CPU")
+	jsonOut := flag.Bool("json", false, "write batch results as JSON lines")
+	timeout := flag.Duration("timeout", 0, "time limit per puzzle, 0 for none")
+	stats := flag.Bool("stats", false, "print solver statistics to stderr")
+	strategies := flag.String("strategies", "", "comma separated list of strategies to use, default all non-optional")
+	simplest := flag.Bool("simplest", false, "apply the simplest available step at a time")
+	flag.Parse()
+
+	opts := sudoku.BatchOptions{Workers: *workers, Timeout: *timeout}
+
+	if *jsonOut {
+		opts.Format = sudoku.BatchJSON
+	}
+
+	if *strategies != "" {
+		opts.Solve.Strategies = strings.Split(*strategies, ",")
+	}
+
+	opts.Solve.Simplest = *simplest
+
+	if *stats {
+		opts.Stats = sudoku.NewStats()
+	}
+
+	var err error
+	if *batch {
+		err = runBatch(flag.Args(), opts)
+	} else {
+		err = runSingle(flag.Args(), opts)
+	}
+
+	if opts.Stats != nil {
+		opts.Stats.WriteTable(os.Stderr)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func runBatch(args []string, opts sudoku.BatchOptions) error {
+	var r io.Reader = os.Stdin
+
+	if len(args) > 1 {
+		return fmt.Errorf("usage: sudoku -batch [flags] [file]")
+	} else if len(args) == 1 {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		r = f
+	}
+
+	_, err := sudoku.BatchSolve(r, os.Stdout, opts)
+	return err
+}
+
+func runSingle(args []string, opts sudoku.BatchOptions) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: sudoku [flags] grid | sudoku -batch [flags] [file]")
+	}
+
+	s, err := sudoku.NewSudoku(strings.Replace(args[0], ".", "0", -1))
+	if err != nil {
+		return err
+	}
+
+	if opts.Stats != nil {
+		s.SetStats(opts.Stats)
+	}
+
+	s.WriteGrid(os.Stdout, sudoku.GridStyle{Layout: sudoku.LayoutASCII})
+
+	ctx := context.Background()
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	res, err := s.SolveContext(ctx, opts.Solve)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(res.Status)
+
+	return s.WriteGrid(os.Stdout, sudoku.GridStyle{Layout: sudoku.LayoutASCII})
+}
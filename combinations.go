@@ -55,6 +55,14 @@ func (c *combination) visit() []int {
 
 // Next get indexes of next combination or nil if generator has been exchausted.
 func (c *combination) next() []int {
+	// Algorithm T needs k < n, otherwise there is at most one combination
+	if c.k >= c.n {
+		if c.k > c.n || !c.visitFlag {
+			return nil
+		}
+		return c.visit()
+	}
+
 	if c.visitFlag {
 		return c.visit()
 	}
@@ -0,0 +1,204 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"math/bits"
+)
+
+// Index of the cell on line r and cross-line c, rows and columns for
+// bands, the other way around for stacks
+func lineIndex(stack bool, r, c int) int {
+	if stack {
+		return c*sudokuNumbers + r
+	}
+
+	return r*sudokuNumbers + c
+}
+
+// A junior exocet has two base cells in a mini-line of a box and a target
+// cell in each of the other boxes of the band, in the other two lines,
+// each in its own line. The base digits are the candidates of the base
+// cells. The cross-lines are the lines crossing the band through the
+// targets and the third cell of the base mini-line. In the S cells, the
+// cross-lines outside the band, each base digit must be covered by two
+// lines parallel to the band, and the cells of the box of a target in
+// its cross-line must not have base digits. Then the two base values are
+// the values of the targets.
+type exocet struct {
+	base    [2]int
+	targets [2]int
+	digits  uint16
+}
+
+// Values of the cells as candidate masks, solved cells included
+func (s *Sudoku) valueMasks() [sudokuGridSize]uint16 {
+	masks := candidateMasks(s.Candidates)
+
+	for i, c := range s.Solved {
+		if c.Value != 0 {
+			masks[i] = 1 << uint(c.Value)
+		}
+	}
+
+	return masks
+}
+
+func (s *Sudoku) findExocetPatterns() []exocet {
+	masks := candidateMasks(s.Candidates)
+	values := s.valueMasks()
+	res := []exocet{}
+
+	for _, stack := range []bool{false, true} {
+		for band := 0; band < 3; band++ {
+			for box := 0; box < 3; box++ {
+				for l := 0; l < 3; l++ {
+					r := band*3 + l
+
+					for skip := 0; skip < 3; skip++ {
+						cols := []int{}
+						for k := 0; k < 3; k++ {
+							if k != skip {
+								cols = append(cols, box*3+k)
+							}
+						}
+
+						b1, b2 := lineIndex(stack, r, cols[0]), lineIndex(stack, r, cols[1])
+						digits := masks[b1] | masks[b2]
+
+						if masks[b1] == 0 || masks[b2] == 0 || bits.OnesCount16(digits) < 3 ||
+							bits.OnesCount16(digits) > 4 {
+							continue
+						}
+
+						res = append(res, s.exocetTargets(stack, band, box, r, box*3+skip, [2]int{b1, b2},
+							digits, &masks, &values)...)
+					}
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+func (s *Sudoku) exocetTargets(stack bool, band, box, r, c3 int, base [2]int, digits uint16,
+	masks, values *[sudokuGridSize]uint16) []exocet {
+	res := []exocet{}
+
+	// The other two lines of the band and the other two boxes
+	lines := []int{}
+	boxes := []int{}
+	for k := 0; k < 3; k++ {
+		if band*3+k != r {
+			lines = append(lines, band*3+k)
+		}
+		if k != box {
+			boxes = append(boxes, k)
+		}
+	}
+
+	for _, order := range [][2]int{{0, 1}, {1, 0}} {
+		r1, r2 := lines[order[0]], lines[order[1]]
+
+		for k1 := 0; k1 < 3; k1++ {
+			for k2 := 0; k2 < 3; k2++ {
+				c1, c2 := boxes[0]*3+k1, boxes[1]*3+k2
+				t1, t2 := lineIndex(stack, r1, c1), lineIndex(stack, r2, c2)
+
+				if masks[t1]&digits == 0 || masks[t2]&digits == 0 {
+					continue
+				}
+
+				// Companions: the cross-line cells of the target boxes
+				// in the other target line
+				if values[lineIndex(stack, r2, c1)]&digits != 0 ||
+					values[lineIndex(stack, r1, c2)]&digits != 0 {
+					continue
+				}
+
+				if !exocetCovered(stack, band, []int{c1, c2, c3}, digits, values) {
+					continue
+				}
+
+				res = append(res, exocet{base: base, targets: [2]int{t1, t2}, digits: digits})
+			}
+		}
+	}
+
+	return res
+}
+
+// exocetCovered tells if each base digit is in at most two lines of the
+// S cells.
+func exocetCovered(stack bool, band int, cross []int, digits uint16, values *[sudokuGridSize]uint16) bool {
+	for _, n := range maskDigits(digits) {
+		bit := uint16(1) << uint(n)
+		lines := 0
+
+		for r := 0; r < sudokuNumbers; r++ {
+			if r/3 == band {
+				continue
+			}
+
+			for _, c := range cross {
+				if values[lineIndex(stack, r, c)]&bit != 0 {
+					lines++
+					break
+				}
+			}
+		}
+
+		if lines > 2 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// findJuniorExocets eliminates the other candidates of the targets, and
+// base digits missing from both targets from the base cells.
+func (s *Sudoku) findJuniorExocets() finderResult {
+	masks := candidateMasks(s.Candidates)
+
+	for _, ex := range s.findExocetPatterns() {
+		found := CellList{}
+
+		for _, t := range ex.targets {
+			found = append(found, s.maskCandidates(t, masks[t]&^ex.digits)...)
+		}
+
+		missing := ex.digits &^ (masks[ex.targets[0]] | masks[ex.targets[1]])
+		for _, b := range ex.base {
+			found = append(found, s.maskCandidates(b, missing)...)
+		}
+
+		if len(found) == 0 {
+			continue
+		}
+
+		pattern := CellList{}
+		for _, idx := range []int{ex.base[0], ex.base[1], ex.targets[0], ex.targets[1]} {
+			pattern = append(pattern, s.maskCandidates(idx, ex.digits)...)
+		}
+
+		return finderResult{Solved: nil, Eliminated: uniqueCells(found), Pattern: pattern}
+	}
+
+	return finderResult{Solved: nil, Eliminated: nil}
+}
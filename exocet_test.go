@@ -0,0 +1,43 @@
+package sudoku_test
+
+import (
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+func TestJuniorExocet(t *testing.T) {
+	// Golden Nugget
+	s, err := sudoku.NewSudoku("000000039000001005003050800008090006070002000100400000009080050020000600400700000")
+	assert.NilError(t, err)
+
+	solution, ok := s.Solution()
+	assert.Assert(t, ok)
+
+	step, ok, err := s.HintWith([]string{"junior exocet"})
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+
+	// Base r1c7 r2c7 (1247), targets r4c8 and r7c9
+	assert.DeepEqual(t, sudoku.CellList{cell(7, 9, 9, 3)}, step.Eliminated)
+	assert.DeepEqual(t, sudoku.CellList{
+		cell(1, 7, 3, 1), cell(1, 7, 3, 2), cell(1, 7, 3, 4), cell(1, 7, 3, 7),
+		cell(2, 7, 3, 2), cell(2, 7, 3, 4), cell(2, 7, 3, 7),
+		cell(4, 8, 6, 1), cell(4, 8, 6, 2), cell(4, 8, 6, 4), cell(4, 8, 6, 7),
+		cell(7, 9, 9, 1), cell(7, 9, 9, 2), cell(7, 9, 9, 4), cell(7, 9, 9, 7),
+	}, step.Pattern)
+
+	assert.NilError(t, s.ApplyStep(step))
+	assertSound(t, s, solution)
+}
+
+func TestJuniorExocetRank(t *testing.T) {
+	rank := map[string]int{}
+	for i, name := range sudoku.AllStrategies() {
+		rank[name] = i
+	}
+
+	assert.Assert(t, rank["junior exocet"] < rank["cell forcing chain"])
+	assert.Assert(t, rank["junior exocet"] < rank["digit forcing chain"])
+}
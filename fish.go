@@ -0,0 +1,207 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"math/bits"
+)
+
+// Lines of a fish: the candidates of the base lines are covered by the
+// cover lines, which are perpendicular to them.
+type fishLines struct {
+	base     func(int8) CellList
+	cover    func(int8) CellList
+	baseIdx  func(Pos) int8
+	coverIdx func(Pos) int8
+}
+
+type fishLine struct {
+	cells CellList
+	mask  uint16
+}
+
+func (s *Sudoku) fishLines() []fishLines {
+	return []fishLines{
+		{s.getCandidateRow, s.getCandidateColumn,
+			func(p Pos) int8 { return p.Row },
+			func(p Pos) int8 { return p.Column },
+		},
+		{s.getCandidateColumn, s.getCandidateRow,
+			func(p Pos) int8 { return p.Column },
+			func(p Pos) int8 { return p.Row },
+		},
+	}
+}
+
+// findFish finds X-Wings (size 2), Swordfish (3) and Jellyfish (4) with
+// rows or columns as the base lines. A finned fish has extra candidates,
+// the fins, in the base lines. The fins must share a box, and only the
+// cells of the cover lines in that box are eliminated. Sashimi fish, that
+// are missing a corner next to the fins, are found as finned ones.
+func (s *Sudoku) findFish(size int, finned bool) finderResult {
+	d := deductions{}
+
+	for _, fl := range s.fishLines() {
+		for n := int8(1); n <= sudokuNumbers; n++ {
+			lines := []fishLine{}
+
+			for i := int8(1); i <= sudokuNumbers; i++ {
+				cells := fl.base(i).Filter(func(c Cell) bool {
+					return c.Value == n
+				})
+
+				if len(cells) < 2 || (!finned && len(cells) > size) {
+					continue
+				}
+
+				line := fishLine{cells: cells}
+				for _, c := range cells {
+					line.mask |= 1 << uint(fl.coverIdx(c.Pos))
+				}
+				lines = append(lines, line)
+			}
+
+			if len(lines) < size {
+				continue
+			}
+
+			comb := newCombination(len(lines), size)
+			for idxs := comb.next(); idxs != nil; idxs = comb.next() {
+				base := make([]fishLine, size)
+				var union uint16
+
+				for i, idx := range idxs {
+					base[i] = lines[idx]
+					union |= lines[idx].mask
+				}
+
+				if finned {
+					d.add(s.finnedFish(fl, n, base, union, size))
+					continue
+				}
+
+				if bits.OnesCount16(union) != size {
+					continue
+				}
+
+				elims, cells := s.fishEliminations(fl, n, base, union, nil)
+				d.add(finderResult{Solved: nil, Eliminated: elims, Pattern: cells})
+			}
+		}
+	}
+
+	return d.result()
+}
+
+// finnedFish tries every set of cover lines that leaves the rest of the
+// base candidates in a single box.
+func (s *Sudoku) finnedFish(fl fishLines, n int8, base []fishLine, union uint16, size int) finderResult {
+	covers := []int8{}
+	for i := int8(1); i <= sudokuNumbers; i++ {
+		if union&(1<<uint(i)) != 0 {
+			covers = append(covers, i)
+		}
+	}
+
+	d := deductions{}
+
+	if len(covers) <= size {
+		return d.result()
+	}
+
+	comb := newCombination(len(covers), size)
+COVERS:
+	for idxs := comb.next(); idxs != nil; idxs = comb.next() {
+		var cover uint16
+		for _, idx := range idxs {
+			cover |= 1 << uint(covers[idx])
+		}
+
+		fins := CellList{}
+		for _, line := range base {
+			if line.mask&cover == 0 {
+				continue COVERS
+			}
+
+			for _, c := range line.cells {
+				if cover&(1<<uint(fl.coverIdx(c.Pos))) == 0 {
+					fins = append(fins, c)
+				}
+			}
+		}
+
+		if !fins.All(func(c Cell) bool { return c.Pos.Box == fins[0].Pos.Box }) {
+			continue
+		}
+
+		elims, cells := s.fishEliminations(fl, n, base, cover, fins)
+		d.add(finderResult{Solved: nil, Eliminated: elims, Pattern: cells})
+	}
+
+	return d.result()
+}
+
+// fishEliminations returns the candidates of the cover lines outside the
+// base lines that see all the fins, and the cells of the fish.
+func (s *Sudoku) fishEliminations(fl fishLines, n int8, base []fishLine, cover uint16, fins CellList) (CellList, CellList) {
+	var baseMask uint16
+	pattern := CellList{}
+
+	for _, line := range base {
+		baseMask |= 1 << uint(fl.baseIdx(line.cells[0].Pos))
+		pattern = append(pattern, line.cells...)
+	}
+
+	found := CellList{}
+	for i := int8(1); i <= sudokuNumbers; i++ {
+		if cover&(1<<uint(i)) == 0 {
+			continue
+		}
+
+		found = append(found, fl.cover(i).Filter(func(c Cell) bool {
+			if c.Value != n || baseMask&(1<<uint(fl.baseIdx(c.Pos))) != 0 {
+				return false
+			}
+
+			return fins.All(func(fin Cell) bool {
+				return c.Pos.sees(fin.Pos)
+			})
+		})...)
+	}
+
+	return found, pattern
+}
+
+func (s *Sudoku) findSwordfish() finderResult {
+	return s.findFish(3, false)
+}
+
+func (s *Sudoku) findJellyfish() finderResult {
+	return s.findFish(4, false)
+}
+
+func (s *Sudoku) findFinnedXWings() finderResult {
+	return s.findFish(2, true)
+}
+
+func (s *Sudoku) findFinnedSwordfish() finderResult {
+	return s.findFish(3, true)
+}
+
+func (s *Sudoku) findFinnedJellyfish() finderResult {
+	return s.findFish(4, true)
+}
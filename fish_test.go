@@ -0,0 +1,113 @@
+package sudoku_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+func TestSwordfish(t *testing.T) {
+	// Rows 3, 6 and 8 have 6 only in columns 2, 5 and 6, and columns 3,
+	// 4 and 8 have it only in rows 5, 7 and 9
+	grid := "007500006640000307010000800400007030000005000802900400000400000005200100700000002"
+
+	step := stalledHint(t, grid, "swordfish")
+	assert.DeepEqual(t, sudoku.CellList{cell(7, 6, 8, 6), cell(9, 2, 7, 6), cell(9, 5, 8, 6), cell(9, 6, 8, 6)},
+		step.Eliminated)
+	assert.DeepEqual(t, sudoku.CellList{
+		cell(3, 5, 2, 6), cell(3, 6, 2, 6), cell(5, 3, 4, 6), cell(5, 4, 5, 6), cell(6, 2, 4, 6),
+		cell(6, 5, 5, 6), cell(6, 6, 5, 6), cell(7, 3, 7, 6), cell(7, 8, 9, 6), cell(8, 2, 7, 6),
+		cell(8, 6, 8, 6), cell(9, 4, 8, 6), cell(9, 8, 9, 6)}, step.Pattern)
+}
+
+func TestJellyfish(t *testing.T) {
+	// Rows 1, 3, 6 and 8 have 1 only in columns 2, 3, 4 and 6, and
+	// columns 1, 5, 8 and 9 have it only in rows 4, 5, 7 and 9
+	grid := "002000000090000100600420050000054090800000000070600025067800000050090008009240007"
+
+	step := stalledHint(t, grid, "jellyfish")
+	assert.DeepEqual(t, sudoku.CellList{cell(5, 2, 4, 1)}, step.Eliminated)
+	assert.DeepEqual(t, sudoku.CellList{
+		cell(1, 2, 1, 1), cell(1, 4, 2, 1), cell(1, 6, 2, 1), cell(3, 2, 1, 1), cell(3, 3, 1, 1),
+		cell(3, 6, 2, 1), cell(4, 1, 4, 1), cell(4, 9, 6, 1), cell(5, 5, 5, 1), cell(5, 9, 6, 1),
+		cell(6, 3, 4, 1), cell(6, 6, 5, 1), cell(7, 5, 8, 1), cell(7, 8, 9, 1), cell(8, 3, 7, 1),
+		cell(8, 4, 8, 1), cell(9, 1, 7, 1), cell(9, 8, 9, 1)}, step.Pattern)
+}
+
+func TestFinnedXWing(t *testing.T) {
+	// Sashimi, r6c2 is missing from the x-wing in rows 3 and 6, the fin
+	// r6c1 sees r4c2 and r5c2
+	grid := "000000020043100050702005900000070000900860002000203000809000006000000409000320007"
+
+	step := stalledHint(t, grid, "finned x-wing")
+	assert.DeepEqual(t, sudoku.CellList{cell(4, 2, 4, 1), cell(5, 2, 4, 1)}, step.Eliminated)
+	assert.DeepEqual(t, sudoku.CellList{cell(3, 2, 1, 1), cell(3, 9, 3, 1), cell(6, 1, 4, 1), cell(6, 9, 6, 1)},
+		step.Pattern)
+}
+
+func TestFinnedSwordfish(t *testing.T) {
+	// Rows 3, 4 and 7 in columns 2, 8 and 9 with the fin r7c7
+	grid := "706500900000700100000002400004900510089007000100050003005080004000640000010000000"
+
+	step := stalledHint(t, grid, "finned swordfish")
+	assert.DeepEqual(t, sudoku.CellList{cell(8, 8, 9, 7)}, step.Eliminated)
+	assert.DeepEqual(t, sudoku.CellList{
+		cell(3, 8, 3, 7), cell(3, 9, 3, 7), cell(4, 2, 4, 7), cell(4, 9, 6, 7),
+		cell(7, 2, 7, 7), cell(7, 7, 9, 7), cell(7, 8, 9, 7)}, step.Pattern)
+}
+
+func TestFinnedJellyfish(t *testing.T) {
+	// Rows 1, 4, 7 and 8 in columns 3, 6, 7 and 8 with the fin r4c9
+	grid := "003400008806079000070005000000300080000000400700094103000510230000006001090000050"
+
+	step := stalledHint(t, grid, "finned jellyfish")
+	assert.DeepEqual(t, sudoku.CellList{cell(5, 8, 6, 7)}, step.Eliminated)
+	assert.DeepEqual(t, sudoku.CellList{
+		cell(1, 7, 3, 7), cell(1, 8, 3, 7), cell(4, 6, 5, 7), cell(4, 7, 6, 7), cell(4, 9, 6, 7),
+		cell(7, 3, 7, 7), cell(7, 6, 8, 7), cell(8, 3, 7, 7), cell(8, 7, 9, 7), cell(8, 8, 9, 7)},
+		step.Pattern)
+}
+
+func TestFishSound(t *testing.T) {
+	grids := []string{
+		"000921003009000060000000500080403006007000800500700040003000000020000700800195000",
+		"390080201000000000015060408006270080000000002900010004680000100040100850000003000",
+		"000000708700300000925840000004930005200050000308000020000106004400000300009000860",
+		"000003001030500682000090500075084020000352007000009400780000200900000100000070003",
+		"009000820000070005000009040005000200000100000082090056020450031006300400040010000",
+		"500009017007010083000072000000008060240050030800200405060000300705006000004020000",
+		"000697200630000000700050000020300105000510002100082004203000050050000609001000080",
+	}
+
+	for _, grid := range grids {
+		s, err := sudoku.NewSudoku(grid)
+		assert.NilError(t, err)
+
+		solution, ok := s.Solution()
+		assert.Assert(t, ok)
+
+		res, err := s.SolveContext(context.Background(),
+			sudoku.SolveOptions{Strategies: sudoku.AllStrategies()})
+		assert.NilError(t, err)
+		assert.Assert(t, res.Status != sudoku.StatusContradiction, grid)
+		assertSound(t, s, solution)
+	}
+}
+
+func TestOptionalStrategies(t *testing.T) {
+	defaults := map[string]bool{}
+	for _, name := range sudoku.Strategies() {
+		defaults[name] = true
+	}
+
+	assert.Assert(t, defaults["swordfish"])
+	assert.Assert(t, defaults["jellyfish"])
+	assert.Assert(t, !defaults["finned x-wing"])
+
+	for _, name := range []string{"skyscraper", "2-string kite", "turbot fish", "empty rectangle"} {
+		assert.Assert(t, defaults[name], name)
+	}
+	assert.Assert(t, len(sudoku.AllStrategies()) > len(defaults))
+}
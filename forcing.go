@@ -0,0 +1,354 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"fmt"
+)
+
+// Limits for forcing chains: steps followed from each assumption and
+// the number of assumptions tried at once
+const (
+	forcingMaxDepth    = 20
+	forcingMaxBranches = 4
+)
+
+// Strategies followed from an assumption
+var forcingPropagation = []strategy{
+	{fun: (*Sudoku).findSinglesSimple, name: "singles (simple)"},
+	{fun: (*Sudoku).findSingles, name: "singles"},
+	{fun: (*Sudoku).findPointingPairs, name: "pointing pairs"},
+	{fun: (*Sudoku).findBoxlineReduction, name: "box/line reduction"},
+}
+
+// ForcingLink is a step following from the assumption of a forcing chain.
+type ForcingLink struct {
+	Strategy   string   `json:"strategy"`
+	Solved     CellList `json:"solved,omitempty"`
+	Eliminated CellList `json:"eliminated,omitempty"`
+}
+
+// ForcingBranch is an assumption of a forcing chain and the steps
+// following from it, up to the conclusion.
+type ForcingBranch struct {
+	Assumption Cell `json:"assumption"`
+	// The assumption is that the candidate is false
+	Negated bool          `json:"negated,omitempty"`
+	Links   []ForcingLink `json:"links,omitempty"`
+	// The contradiction the assumption leads to
+	Contradiction string `json:"contradiction,omitempty"`
+}
+
+// A branch of a forcing chain being followed
+type forcingState struct {
+	s      *Sudoku
+	branch ForcingBranch
+	steps  []Step
+}
+
+// validateHouses checks that every number is solved or a candidate in
+// each row, column and box.
+func (s *Sudoku) validateHouses() error {
+	masks := candidateMasks(s.Candidates)
+	var found [3 * sudokuNumbers]uint16
+
+	for i, cell := range s.Solved {
+		for _, h := range houses(cell.Pos) {
+			found[h] |= masks[i] | 1<<uint(cell.Value)
+		}
+	}
+
+	for h, mask := range found {
+		for n := int8(1); n <= sudokuNumbers; n++ {
+			if mask&(1<<uint(n)) == 0 {
+				return fmt.Errorf("No place left for %d in %s", n, houseName(h))
+			}
+		}
+	}
+
+	return nil
+}
+
+// follow makes the assumption on a snapshot of the grid and applies the
+// propagation strategies until they stall, the depth limit is reached or
+// a contradiction is found.
+func (s *Sudoku) follow(assumption Cell, negated bool) *forcingState {
+	c := s.clone()
+	c.trace = &Trace{}
+
+	if negated {
+		c.updateCandidates(CellList{assumption})
+	} else {
+		c.updateSolved(CellList{assumption})
+	}
+
+	fs := &forcingState{s: c, branch: ForcingBranch{Assumption: assumption, Negated: negated}}
+
+	for depth := 0; ; depth++ {
+		err := c.validate()
+		if err == nil {
+			err = c.validateHouses()
+		}
+		if err != nil {
+			fs.branch.Contradiction = err.Error()
+			break
+		}
+
+		if depth == forcingMaxDepth || len(c.Candidates) == 0 {
+			break
+		}
+
+		progress := false
+		for _, st := range forcingPropagation {
+			if c.apply(st.name, st.fun(c)) {
+				progress = true
+				break
+			}
+		}
+
+		if !progress {
+			break
+		}
+	}
+
+	fs.steps = c.trace.Steps
+	c.trace = nil
+
+	return fs
+}
+
+// establishedAt returns the number of steps it took to place or
+// eliminate the candidate, zero if the assumption itself did it.
+func (fs *forcingState) establishedAt(c Cell, placed bool) int {
+	bit := uint16(1) << uint(c.Value)
+
+	for i, step := range fs.steps {
+		if placed && step.Solved.Any(func(o Cell) bool { return o == c }) {
+			return i + 1
+		}
+
+		if !placed && candidateMasks(step.Before)[c.Pos.index()]&bit != 0 &&
+			candidateMasks(step.After)[c.Pos.index()]&bit == 0 {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// proof returns the branch with its first steps
+func (fs *forcingState) proof(steps int) ForcingBranch {
+	branch := fs.branch
+
+	for _, step := range fs.steps[:steps] {
+		branch.Links = append(branch.Links, ForcingLink{
+			Strategy: step.Strategy, Solved: step.Solved, Eliminated: step.Eliminated,
+		})
+	}
+
+	return branch
+}
+
+// forcing follows each of the assumptions, one of which must be true. An
+// assumption leading to a contradiction is false, otherwise placements
+// and eliminations common to all branches are true. Only the ones with
+// the shortest chains are returned to keep the proof compact.
+func (s *Sudoku) forcing(assumptions CellList, negated []bool) finderResult {
+	none := finderResult{Solved: nil, Eliminated: nil}
+
+	states := make([]*forcingState, len(assumptions))
+
+	for i, assumption := range assumptions {
+		fs := s.follow(assumption, negated[i])
+
+		if fs.branch.Contradiction != "" {
+			res := finderResult{Pattern: CellList{assumption}, Chain: []ForcingBranch{fs.proof(len(fs.steps))}}
+			if negated[i] {
+				res.Solved = CellList{assumption}
+			} else {
+				res.Eliminated = CellList{assumption}
+			}
+			return res
+		}
+
+		states[i] = fs
+	}
+
+	type consequence struct {
+		cell   Cell
+		placed bool
+		steps  []int
+	}
+
+	conseqs := []consequence{}
+	add := func(c Cell, placed bool) {
+		steps := make([]int, len(states))
+		for i, fs := range states {
+			steps[i] = fs.establishedAt(c, placed)
+		}
+		conseqs = append(conseqs, consequence{cell: c, placed: placed, steps: steps})
+	}
+
+	for i, cell := range s.Solved {
+		if cell.Value != 0 {
+			continue
+		}
+
+		value := states[0].s.Solved[i].Value
+		common := value != 0
+
+		for _, fs := range states[1:] {
+			common = common && fs.s.Solved[i].Value == value
+		}
+
+		if common {
+			add(Cell{Pos: cell.Pos, Value: value}, true)
+		}
+	}
+
+	for _, c := range s.Candidates {
+		idx := c.Pos.index()
+		common := true
+
+		for _, fs := range states {
+			if fs.s.Solved[idx].Value == c.Value ||
+				candidateMasks(fs.s.Candidates)[idx]&(1<<uint(c.Value)) != 0 {
+				common = false
+				break
+			}
+		}
+
+		if common {
+			add(c, false)
+		}
+	}
+
+	if len(conseqs) == 0 {
+		return none
+	}
+
+	// Length of the longest branch
+	length := func(cq consequence) int {
+		return intList(cq.steps).Reduce(0, func(acc, n int) int {
+			if n > acc {
+				return n
+			}
+			return acc
+		})
+	}
+
+	shortest := length(conseqs[0])
+	for _, cq := range conseqs[1:] {
+		if l := length(cq); l < shortest {
+			shortest = l
+		}
+	}
+
+	res := finderResult{Solved: CellList{}, Eliminated: CellList{}, Pattern: copyCells(assumptions)}
+	last := make([]int, len(states))
+
+	for _, cq := range conseqs {
+		if length(cq) != shortest {
+			continue
+		}
+
+		if cq.placed {
+			res.Solved = append(res.Solved, cq.cell)
+		} else {
+			res.Eliminated = append(res.Eliminated, cq.cell)
+		}
+
+		for i, n := range cq.steps {
+			if n > last[i] {
+				last[i] = n
+			}
+		}
+	}
+
+	for i, fs := range states {
+		res.Chain = append(res.Chain, fs.proof(last[i]))
+	}
+
+	return res
+}
+
+func forcingFlags(n int, negated bool) []bool {
+	res := make([]bool, n)
+
+	for i := range res {
+		res[i] = negated
+	}
+
+	return res
+}
+
+// Each candidate of a cell is assumed in turn
+func (s *Sudoku) findCellForcingChains() finderResult {
+	for _, pos := range s.ucpos() {
+		cands := s.Candidates.Filter(func(c Cell) bool {
+			return c.Pos == pos
+		})
+
+		if len(cands) < 2 || len(cands) > forcingMaxBranches {
+			continue
+		}
+
+		if res := s.forcing(cands, forcingFlags(len(cands), false)); len(res.Solved) > 0 || len(res.Eliminated) > 0 {
+			return res
+		}
+	}
+
+	return finderResult{Solved: nil, Eliminated: nil}
+}
+
+// Each position of a number in a house is assumed in turn
+func (s *Sudoku) findRegionForcingChains() finderResult {
+	funs := []cellGetter{s.getCandidateRow, s.getCandidateColumn, s.getCandidateBox}
+
+	for _, fun := range funs {
+		for i := int8(1); i <= sudokuNumbers; i++ {
+			cells := fun(i)
+
+			for n := int8(1); n <= sudokuNumbers; n++ {
+				ncells := cells.Filter(func(c Cell) bool {
+					return c.Value == n
+				})
+
+				if len(ncells) < 2 || len(ncells) > forcingMaxBranches {
+					continue
+				}
+
+				if res := s.forcing(ncells, forcingFlags(len(ncells), false)); len(res.Solved) > 0 || len(res.Eliminated) > 0 {
+					return res
+				}
+			}
+		}
+	}
+
+	return finderResult{Solved: nil, Eliminated: nil}
+}
+
+// A candidate is assumed to be both true and false
+func (s *Sudoku) findDigitForcingChains() finderResult {
+	for _, c := range copyCells(s.Candidates) {
+		if res := s.forcing(CellList{c, c}, []bool{false, true}); len(res.Solved) > 0 || len(res.Eliminated) > 0 {
+			return res
+		}
+	}
+
+	return finderResult{Solved: nil, Eliminated: nil}
+}
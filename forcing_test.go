@@ -0,0 +1,105 @@
+package sudoku_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+var forcingStrategies = []string{"cell forcing chain", "region forcing chain", "digit forcing chain"}
+
+func forcingState(t *testing.T, grid string) *sudoku.Sudoku {
+	t.Helper()
+
+	s, err := sudoku.NewSudoku(grid)
+	assert.NilError(t, err)
+
+	res, err := s.SolveContext(context.Background(), sudoku.SolveOptions{Strategies: baseStrategies})
+	assert.NilError(t, err)
+	assert.Equal(t, sudoku.StatusStalled, res.Status)
+
+	return s
+}
+
+func TestCellForcingChain(t *testing.T) {
+	s := forcingState(t, "009000702300006000070005000003000000081072090000601007500100400007040109000020006")
+	grid, count := s.GetGridString(), len(s.Candidates)
+
+	step, ok, err := s.HintWith([]string{"cell forcing chain"})
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+
+	// r1c1 is 1 or 8, either way 4 is not in r9c2
+	assert.DeepEqual(t, sudoku.CellList{cell(1, 1, 1, 1), cell(1, 1, 1, 8)}, step.Pattern)
+	assert.DeepEqual(t, sudoku.CellList{cell(9, 2, 7, 4)}, step.Eliminated)
+	assert.Equal(t, 2, len(step.Chain))
+	assert.DeepEqual(t, []sudoku.ForcingLink{{Strategy: "singles", Solved: sudoku.CellList{cell(9, 2, 7, 1)}}},
+		step.Chain[0].Links)
+	assert.Equal(t, cell(1, 1, 1, 8), step.Chain[1].Assumption)
+	assert.Equal(t, "", step.Chain[1].Contradiction)
+
+	// The branches are followed on snapshots
+	assert.Equal(t, grid, s.GetGridString())
+	assert.Equal(t, count, len(s.Candidates))
+}
+
+func TestRegionForcingChain(t *testing.T) {
+	s := forcingState(t, "000000708700300000925840000004930005200050000308000020000106004400000300009000860")
+
+	step, ok, err := s.HintWith([]string{"region forcing chain"})
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+
+	// 1 in row 1 at r1c1 leaves no place for 9 in row 7
+	assert.DeepEqual(t, sudoku.CellList{cell(1, 1, 1, 1)}, step.Eliminated)
+	assert.Equal(t, 1, len(step.Chain))
+	assert.Equal(t, cell(1, 1, 1, 1), step.Chain[0].Assumption)
+	assert.Equal(t, 4, len(step.Chain[0].Links))
+	assert.Equal(t, "No place left for 9 in row 7", step.Chain[0].Contradiction)
+}
+
+func TestForcingContradiction(t *testing.T) {
+	s := forcingState(t, "300710050070400309600200700020070006850000190000000005030005040060009000000000001")
+
+	step, ok, err := s.HintWith([]string{"cell forcing chain"})
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, sudoku.CellList{cell(1, 2, 1, 9)}, step.Eliminated)
+	assert.Equal(t, 1, len(step.Chain))
+	assert.Equal(t, 3, len(step.Chain[0].Links))
+	assert.Equal(t, "No candidates left for cell (7, 9)", step.Chain[0].Contradiction)
+
+	// Without 4 r1c2 is 9, leading to the same contradiction
+	step, ok, err = s.HintWith([]string{"digit forcing chain"})
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, sudoku.CellList{cell(1, 2, 1, 4)}, step.Solved)
+	assert.Assert(t, step.Chain[0].Negated)
+	assert.Equal(t, 4, len(step.Chain[0].Links))
+}
+
+func TestForcingSound(t *testing.T) {
+	grids := []string{
+		"009000702300006000070005000003000000081072090000601007500100400007040109000020006",
+		"300710050070400309600200700020070006850000190000000005030005040060009000000000001",
+		"000921003009000060000000500080403006007000800500700040003000000020000700800195000",
+	}
+
+	for _, grid := range grids {
+		for _, name := range forcingStrategies {
+			s := forcingState(t, grid)
+
+			solution, ok := s.Solution()
+			assert.Assert(t, ok)
+
+			res, err := s.SolveContext(context.Background(), sudoku.SolveOptions{
+				Strategies: append(copyNames(baseStrategies), name),
+			})
+			assert.NilError(t, err)
+			assert.Equal(t, sudoku.StatusSolved, res.Status, "%s %s", grid, name)
+			assert.Equal(t, solution, s.GetGridString())
+		}
+	}
+}
@@ -0,0 +1,403 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotUnique is returned for puzzles without a unique solution.
+var ErrNotUnique = errors.New("Puzzle does not have a unique solution")
+
+type cellState struct {
+	value int8
+	marks uint16
+}
+
+type cellChange struct {
+	idx           int
+	before, after cellState
+}
+
+// Game is a puzzle being played: the givens, the values and pencil marks
+// entered by the player and the history of moves.
+type Game struct {
+	givens   [sudokuGridSize]bool
+	cells    [sudokuGridSize]cellState
+	solution [sudokuGridSize]int8
+
+	undo [][]cellChange
+	redo [][]cellChange
+}
+
+// NewGame starts a game of the grid, which must have a unique solution.
+func NewGame(grid string) (*Game, error) {
+	s, err := NewSudoku(grid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateSolved(); err != nil {
+		return nil, err
+	}
+
+	sols := bruteForceSolutions(s.gridValues(), 2)
+	if len(sols) != 1 {
+		return nil, ErrNotUnique
+	}
+
+	g := Game{solution: sols[0]}
+	for i, cell := range s.Solved {
+		g.givens[i] = cell.Value != 0
+		g.cells[i].value = cell.Value
+	}
+
+	return &g, nil
+}
+
+func gameIndex(row, col int8) (int, error) {
+	if row < 1 || row > sudokuNumbers || col < 1 || col > sudokuNumbers {
+		return 0, fmt.Errorf("Invalid cell (%d, %d)", row, col)
+	}
+
+	return int(row-1)*sudokuNumbers + int(col-1), nil
+}
+
+func (g *Game) editableIndex(row, col int8) (int, error) {
+	idx, err := gameIndex(row, col)
+	if err != nil {
+		return 0, err
+	}
+
+	if g.givens[idx] {
+		return 0, fmt.Errorf("Cell (%d, %d) is a given", row, col)
+	}
+
+	return idx, nil
+}
+
+func validNumber(n int8) error {
+	if n < 1 || n > sudokuNumbers {
+		return fmt.Errorf("Invalid number %d", n)
+	}
+
+	return nil
+}
+
+func (g *Game) commit(changes []cellChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	for _, ch := range changes {
+		g.cells[ch.idx] = ch.after
+	}
+
+	g.undo = append(g.undo, changes)
+	g.redo = nil
+}
+
+// Given tells if the cell is a given.
+func (g *Game) Given(row, col int8) bool {
+	idx, err := gameIndex(row, col)
+	return err == nil && g.givens[idx]
+}
+
+// Value returns the value of the cell, zero if empty.
+func (g *Game) Value(row, col int8) int8 {
+	idx, err := gameIndex(row, col)
+	if err != nil {
+		return 0
+	}
+
+	return g.cells[idx].value
+}
+
+// Marks returns the pencil marks of the cell.
+func (g *Game) Marks(row, col int8) []int8 {
+	idx, err := gameIndex(row, col)
+	if err != nil {
+		return nil
+	}
+
+	res := []int8{}
+	for n := int8(1); n <= sudokuNumbers; n++ {
+		if g.cells[idx].marks&(1<<uint(n)) != 0 {
+			res = append(res, n)
+		}
+	}
+
+	return res
+}
+
+// Grid returns the givens and the entered values as a grid string.
+func (g *Game) Grid() string {
+	var values [sudokuGridSize]int8
+
+	for i, cell := range g.cells {
+		values[i] = cell.value
+	}
+
+	return valuesString(values)
+}
+
+// placeChanges enters n and clears the pencil marks of the cell and the
+// marks of n from its peers.
+func (g *Game) placeChanges(idx int, n int8) []cellChange {
+	pos := Pos{}.init(int8(idx/sudokuNumbers+1), int8(idx%sudokuNumbers+1))
+	bit := uint16(1) << uint(n)
+
+	changes := []cellChange{{idx: idx, before: g.cells[idx], after: cellState{value: n}}}
+
+	for i, cell := range g.cells {
+		if i == idx || cell.marks&bit == 0 {
+			continue
+		}
+
+		other := Pos{}.init(int8(i/sudokuNumbers+1), int8(i%sudokuNumbers+1))
+		if pos.sees(other) {
+			after := cell
+			after.marks &^= bit
+			changes = append(changes, cellChange{idx: i, before: cell, after: after})
+		}
+	}
+
+	return changes
+}
+
+// SetValue enters a value into a cell.
+func (g *Game) SetValue(row, col, n int8) error {
+	idx, err := g.editableIndex(row, col)
+	if err != nil {
+		return err
+	}
+
+	if err := validNumber(n); err != nil {
+		return err
+	}
+
+	g.commit(g.placeChanges(idx, n))
+
+	return nil
+}
+
+// Erase clears the value and the pencil marks of a cell.
+func (g *Game) Erase(row, col int8) error {
+	idx, err := g.editableIndex(row, col)
+	if err != nil {
+		return err
+	}
+
+	if g.cells[idx] != (cellState{}) {
+		g.commit([]cellChange{{idx: idx, before: g.cells[idx]}})
+	}
+
+	return nil
+}
+
+// ToggleMark toggles the pencil mark n of an empty cell.
+func (g *Game) ToggleMark(row, col, n int8) error {
+	idx, err := g.editableIndex(row, col)
+	if err != nil {
+		return err
+	}
+
+	if err := validNumber(n); err != nil {
+		return err
+	}
+
+	if g.cells[idx].value != 0 {
+		return fmt.Errorf("Cell (%d, %d) has a value", row, col)
+	}
+
+	after := g.cells[idx]
+	after.marks ^= 1 << uint(n)
+	g.commit([]cellChange{{idx: idx, before: g.cells[idx], after: after}})
+
+	return nil
+}
+
+// Undo undoes the latest move, false if there is none.
+func (g *Game) Undo() bool {
+	if len(g.undo) == 0 {
+		return false
+	}
+
+	changes := g.undo[len(g.undo)-1]
+	g.undo = g.undo[:len(g.undo)-1]
+
+	for i := len(changes) - 1; i >= 0; i-- {
+		g.cells[changes[i].idx] = changes[i].before
+	}
+
+	g.redo = append(g.redo, changes)
+
+	return true
+}
+
+// Redo redoes the latest undone move, false if there is none.
+func (g *Game) Redo() bool {
+	if len(g.redo) == 0 {
+		return false
+	}
+
+	changes := g.redo[len(g.redo)-1]
+	g.redo = g.redo[:len(g.redo)-1]
+
+	for _, ch := range changes {
+		g.cells[ch.idx] = ch.after
+	}
+
+	g.undo = append(g.undo, changes)
+
+	return true
+}
+
+func (g *Game) cellsWhere(pred func(idx int) bool) CellList {
+	res := CellList{}
+
+	for i, cell := range g.cells {
+		if pred(i) {
+			res = append(res, Cell{}.init(int8(i/sudokuNumbers+1), int8(i%sudokuNumbers+1), cell.value))
+		}
+	}
+
+	return res
+}
+
+// Mistakes returns the entered values that differ from the solution.
+func (g *Game) Mistakes() CellList {
+	return g.cellsWhere(func(i int) bool {
+		return !g.givens[i] && g.cells[i].value != 0 && g.cells[i].value != g.solution[i]
+	})
+}
+
+// Conflicts returns the cells whose value is repeated in a row, column
+// or box.
+func (g *Game) Conflicts() CellList {
+	cells := g.cellsWhere(func(i int) bool { return g.cells[i].value != 0 })
+
+	return cells.Filter(func(c Cell) bool {
+		return cells.Any(func(other Cell) bool {
+			return c.Pos != other.Pos && c.eqValue(other) && c.Pos.sees(other.Pos)
+		})
+	})
+}
+
+// Solved tells if every cell has the value of the solution.
+func (g *Game) Solved() bool {
+	for i, cell := range g.cells {
+		if cell.value != g.solution[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sudoku returns the entered values as a sudoku, with the candidates of
+// the cells that have pencil marks limited to the marks.
+func (g *Game) sudoku() (*Sudoku, error) {
+	s, err := NewSudoku(g.Grid())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateSolved(); err != nil {
+		return nil, err
+	}
+
+	eliminated := s.Candidates.Filter(func(c Cell) bool {
+		marks := g.cells[c.Pos.index()].marks
+		return marks != 0 && marks&(1<<uint(c.Value)) == 0
+	})
+
+	if err := s.ApplyStep(Step{Strategy: "pencil marks", Eliminated: eliminated}); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Hint returns the next step from the entered values, taking the pencil
+// marks into account.
+func (g *Game) Hint() (Step, bool, error) {
+	s, err := g.sudoku()
+	if err != nil {
+		return Step{}, false, err
+	}
+
+	step, ok := s.Hint()
+
+	return step, ok, nil
+}
+
+// ApplyHint enters the values placed by the step and removes the
+// eliminated candidates from the pencil marks. Cells without marks get
+// their current candidates as marks first. The step is a single move.
+func (g *Game) ApplyHint(step Step) error {
+	s, err := g.sudoku()
+	if err != nil {
+		return err
+	}
+
+	masks := candidateMasks(s.Candidates)
+	after := g.cells
+	changed := make(map[int]bool)
+
+	for _, c := range step.Eliminated {
+		idx := c.Pos.index()
+		if g.givens[idx] || after[idx].value != 0 {
+			continue
+		}
+
+		if after[idx].marks == 0 {
+			after[idx].marks = masks[idx]
+		}
+		after[idx].marks &^= 1 << uint(c.Value)
+		changed[idx] = true
+	}
+
+	saved := g.cells
+	g.cells = after
+
+	changes := []cellChange{}
+	for _, c := range step.Solved {
+		idx := c.Pos.index()
+		if g.givens[idx] {
+			g.cells = saved
+			return fmt.Errorf("Cell (%d, %d) is a given", c.Pos.Row, c.Pos.Column)
+		}
+
+		for _, ch := range g.placeChanges(idx, c.Value) {
+			g.cells[ch.idx] = ch.after
+			changed[ch.idx] = true
+		}
+	}
+
+	for idx := range g.cells {
+		if changed[idx] && saved[idx] != g.cells[idx] {
+			changes = append(changes, cellChange{idx: idx, before: saved[idx], after: g.cells[idx]})
+		}
+	}
+
+	g.cells = saved
+	g.commit(changes)
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package sudoku_test
+
+import (
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+const gameGrid = "000040700500780020070002006810007900460000051009600078900800010080064009002050000"
+const gameSolution = "628341795594786123173592846815437962467928351239615478956873214781264539342159687"
+
+func TestGameMoves(t *testing.T) {
+	g, err := sudoku.NewGame(gameGrid)
+	assert.NilError(t, err)
+
+	assert.Assert(t, g.Given(1, 5))
+	assert.Error(t, g.SetValue(1, 5, 3), "Cell (1, 5) is a given")
+	assert.Error(t, g.SetValue(1, 1, 10), "Invalid number 10")
+
+	assert.NilError(t, g.ToggleMark(1, 1, 6))
+	assert.NilError(t, g.ToggleMark(1, 1, 3))
+	assert.NilError(t, g.ToggleMark(1, 2, 6))
+	assert.NilError(t, g.ToggleMark(3, 3, 6))
+	assert.NilError(t, g.ToggleMark(9, 2, 6))
+	assert.DeepEqual(t, []int8{3, 6}, g.Marks(1, 1))
+
+	// Entering a value clears its marks and the marks of its peers
+	assert.NilError(t, g.SetValue(1, 1, 6))
+	assert.Equal(t, int8(6), g.Value(1, 1))
+	assert.DeepEqual(t, []int8{}, g.Marks(1, 1))
+	assert.DeepEqual(t, []int8{}, g.Marks(1, 2))
+	assert.DeepEqual(t, []int8{}, g.Marks(3, 3))
+	assert.DeepEqual(t, []int8{6}, g.Marks(9, 2))
+
+	assert.Assert(t, g.Undo())
+	assert.Equal(t, int8(0), g.Value(1, 1))
+	assert.DeepEqual(t, []int8{3, 6}, g.Marks(1, 1))
+	assert.DeepEqual(t, []int8{6}, g.Marks(1, 2))
+
+	assert.Assert(t, g.Redo())
+	assert.Equal(t, int8(6), g.Value(1, 1))
+	assert.DeepEqual(t, []int8{}, g.Marks(1, 2))
+	assert.Assert(t, !g.Redo())
+
+	// A new move clears the redo history
+	assert.Assert(t, g.Undo())
+	assert.NilError(t, g.Erase(1, 1))
+	assert.Assert(t, !g.Redo())
+	assert.DeepEqual(t, []int8{}, g.Marks(1, 1))
+}
+
+func TestGameMistakes(t *testing.T) {
+	g, err := sudoku.NewGame(gameGrid)
+	assert.NilError(t, err)
+
+	assert.NilError(t, g.SetValue(1, 1, 4))
+	assert.NilError(t, g.SetValue(1, 2, 2))
+
+	mistakes := g.Mistakes()
+	assert.Equal(t, 1, len(mistakes))
+	assert.Equal(t, int8(1), mistakes[0].Pos.Column)
+
+	// 4 is also in row 1 and column 1
+	conflicts := g.Conflicts()
+	assert.Equal(t, 3, len(conflicts))
+
+	_, _, err = g.Hint()
+	assert.Assert(t, err != nil)
+
+	assert.Assert(t, g.Undo())
+	assert.Assert(t, g.Undo())
+	assert.Assert(t, !g.Undo())
+	assert.Equal(t, gameGrid, g.Grid())
+
+	_, err = sudoku.NewGame("000000000000000000000000000000000000000000000000000000000000000000000000000000000")
+	assert.Equal(t, sudoku.ErrNotUnique, err)
+}
+
+func TestGameHints(t *testing.T) {
+	g, err := sudoku.NewGame(gameGrid)
+	assert.NilError(t, err)
+
+	for i := 0; i < 200 && !g.Solved(); i++ {
+		step, ok, err := g.Hint()
+		assert.NilError(t, err)
+		assert.Assert(t, ok)
+		assert.NilError(t, g.ApplyHint(step))
+		assert.Equal(t, 0, len(g.Mistakes()))
+	}
+
+	assert.Assert(t, g.Solved())
+	assert.Equal(t, gameSolution, g.Grid())
+
+	// Applying a hint is a single move
+	g, err = sudoku.NewGame(gameGrid)
+	assert.NilError(t, err)
+
+	step, _, err := g.Hint()
+	assert.NilError(t, err)
+	assert.NilError(t, g.ApplyHint(step))
+	assert.Assert(t, g.Undo())
+	assert.Equal(t, gameGrid, g.Grid())
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// GenerateOptions control Generate.
+type GenerateOptions struct {
+	// Seed of the random generator, zero means a time based seed
+	Seed int64
+	// Remove clues in pairs that are symmetric around the center
+	Symmetric bool
+	// Require that Solve can solve the puzzle
+	Solvable bool
+	// Number of puzzles to try before giving up on Solvable, zero
+	// means 100
+	Attempts int
+}
+
+// ErrNoPuzzle is returned when no puzzle satisfying the options was found.
+var ErrNoPuzzle = errors.New("No puzzle found")
+
+// Generate generates a random puzzle with a unique solution. No clue can
+// be removed from the puzzle, or pair of clues if symmetric, without
+// losing uniqueness.
+func Generate(opts GenerateOptions) (string, error) {
+	return GenerateContext(context.Background(), opts)
+}
+
+// GenerateContext is like Generate, but stops when ctx is done.
+func GenerateContext(ctx context.Context, opts GenerateOptions) (string, error) {
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rnd := rand.New(rand.NewSource(seed))
+
+	attempts := opts.Attempts
+	if attempts <= 0 {
+		attempts = 100
+	}
+
+	for i := 0; i < attempts; i++ {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		grid := generatePuzzle(rnd, opts.Symmetric)
+
+		if !opts.Solvable {
+			return grid, nil
+		}
+
+		s, err := NewSudoku(grid)
+		if err != nil {
+			return "", err
+		}
+
+		res, err := s.SolveContext(ctx, SolveOptions{})
+		if err != nil {
+			return "", err
+		}
+
+		if res.Status == StatusSolved {
+			return grid, nil
+		}
+	}
+
+	return "", ErrNoPuzzle
+}
+
+func randomSolution(rnd *rand.Rand) [sudokuGridSize]int8 {
+	b := newBruteForce([sudokuGridSize]int8{})
+	b.rnd = rnd
+	b.limit = 1
+	b.search()
+
+	return b.solutions[0]
+}
+
+func generatePuzzle(rnd *rand.Rand, symmetric bool) string {
+	values := randomSolution(rnd)
+
+	for _, idx := range rnd.Perm(sudokuGridSize) {
+		if values[idx] == 0 {
+			continue
+		}
+
+		mirror := sudokuGridSize - 1 - idx
+		v, mv := values[idx], values[mirror]
+
+		values[idx] = 0
+		if symmetric {
+			values[mirror] = 0
+		}
+
+		if len(bruteForceSolutions(values, 2)) != 1 {
+			values[idx] = v
+			values[mirror] = mv
+		}
+	}
+
+	return valuesString(values)
+}
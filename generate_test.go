@@ -0,0 +1,184 @@
+package sudoku_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+func TestSolutions(t *testing.T) {
+	sols, err := sudoku.Solutions("000704005020010070000080002090006250600070008053200010400090000030060090200407000", 2)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(sols))
+	assert.Equal(t, "981724365324615879765983142197836254642571938853249716476398521538162497219457683", sols[0])
+
+	s, err := sudoku.NewSudoku("000704005020010070000080002090006250600070008053200010400090000030060090200407000")
+	assert.NilError(t, err)
+	assert.Assert(t, !s.Solve())
+
+	sol, ok := s.Solution()
+	assert.Assert(t, ok)
+	assert.Equal(t, sols[0], sol)
+
+	// Solution of the stalled grid agrees with the placements made
+	grid := s.GetGridString()
+	for i := range grid {
+		if grid[i] != '0' {
+			assert.Equal(t, sol[i], grid[i])
+		}
+	}
+
+	sols, err = sudoku.Solutions("000000000000000000000000000000000000000000000000000000000000000000000000000000000", 5)
+	assert.NilError(t, err)
+	assert.Equal(t, 5, len(sols))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = sudoku.SolutionsContext(ctx, "000000000000000000000000000000000000000000000000000000000000000000000000000000000", 5)
+	assert.Equal(t, context.Canceled, err)
+
+	for _, limit := range []int{0, -1} {
+		_, err = sudoku.Solutions("000704005020010070000080002090006250600070008053200010400090000030060090200407000", limit)
+		assert.ErrorContains(t, err, "Invalid solution limit")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	grid1, err := sudoku.Generate(sudoku.GenerateOptions{Seed: 1})
+	assert.NilError(t, err)
+
+	grid2, err := sudoku.Generate(sudoku.GenerateOptions{Seed: 1})
+	assert.NilError(t, err)
+	assert.Equal(t, grid1, grid2)
+
+	sols, err := sudoku.Solutions(grid1, 2)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(sols))
+
+	grid, err := sudoku.Generate(sudoku.GenerateOptions{Seed: 2, Symmetric: true, Solvable: true})
+	assert.NilError(t, err)
+
+	s, err := sudoku.NewSudoku(grid)
+	assert.NilError(t, err)
+	assert.Assert(t, s.Solve())
+
+	for i := 0; i < 81; i++ {
+		assert.Equal(t, grid[i] == '0', grid[80-i] == '0')
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	grid := "000040700500780020070002006810007900460000051009600078900800010080064009002050000"
+
+	// Transpose, swap the first two bands, swap the first two columns
+	// and relabel numbers
+	relabel := []byte("0918273645")
+	var b [81]byte
+	for r := 0; r < 9; r++ {
+		for c := 0; c < 9; c++ {
+			b[r*9+c] = relabel[grid[c*9+r]-'0']
+		}
+	}
+	var b2 [81]byte
+	for r := 0; r < 9; r++ {
+		nr := (r + 3) % 6
+		if r >= 6 {
+			nr = r
+		}
+		for c := 0; c < 9; c++ {
+			nc := c
+			if c < 2 {
+				nc = 1 - c
+			}
+			b2[nr*9+nc] = b[r*9+c]
+		}
+	}
+
+	c1, err := sudoku.Canonical(grid)
+	assert.NilError(t, err)
+
+	c2, err := sudoku.Canonical(string(b2[:]))
+	assert.NilError(t, err)
+
+	assert.Equal(t, c1, c2)
+	assert.Assert(t, c1 <= grid)
+}
+
+// permutation3 returns a random permutation of 0..8 that keeps the
+// groups of three together
+func permutation3(rnd *rand.Rand) []int {
+	res := []int{}
+
+	for _, g := range rnd.Perm(3) {
+		for _, i := range rnd.Perm(3) {
+			res = append(res, g*3+i)
+		}
+	}
+
+	return res
+}
+
+func TestCanonicalInvariance(t *testing.T) {
+	grids := []string{
+		"000040700500780020070002006810007900460000051009600078900800010080064009002050000",
+		"000921003009000060000000500080403006007000800500700040003000000020000700800195000",
+	}
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, grid := range grids {
+		want, err := sudoku.Canonical(grid)
+		assert.NilError(t, err)
+		assert.Assert(t, want <= grid)
+
+		for i := 0; i < 40; i++ {
+			rows, cols := permutation3(rnd), permutation3(rnd)
+			relabel := append([]int{0}, rnd.Perm(9)...)
+			transpose := rnd.Intn(2) == 1
+
+			var b [81]byte
+			for r := 0; r < 9; r++ {
+				for c := 0; c < 9; c++ {
+					src := rows[r]*9 + cols[c]
+					if transpose {
+						src = cols[c]*9 + rows[r]
+					}
+
+					v := int(grid[src] - '0')
+					if v != 0 {
+						v = relabel[v] + 1
+					}
+					b[r*9+c] = byte('0' + v)
+				}
+			}
+
+			got, err := sudoku.Canonical(string(b[:]))
+			assert.NilError(t, err)
+			assert.Equal(t, want, got, string(b[:]))
+		}
+	}
+}
+
+func TestHint(t *testing.T) {
+	s, err := sudoku.NewSudoku("000704005020010070000080002090006250600070008053200010400090000030060090200407000")
+	assert.NilError(t, err)
+
+	before := s.GetGridString()
+
+	step, ok := s.Hint()
+	assert.Assert(t, ok)
+	assert.Equal(t, "singles (simple)", step.Strategy)
+	assert.Equal(t, before, s.GetGridString())
+
+	step, ok, err = s.HintWith([]string{"pointing pairs"})
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.Assert(t, len(step.Eliminated) > 0 && len(step.Pattern) > 0)
+
+	assert.Assert(t, !s.Solve())
+	_, ok = s.Hint()
+	assert.Assert(t, !ok)
+}
@@ -0,0 +1,117 @@
+package sudoku_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+func cell(row, col, box, value int8) sudoku.Cell {
+	return sudoku.Cell{Pos: sudoku.Pos{Row: row, Column: col, Box: box}, Value: value}
+}
+
+// captureStdout returns what f writes to the standard output
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	assert.NilError(t, err)
+
+	stdout := os.Stdout
+	os.Stdout = w
+	f()
+	os.Stdout = stdout
+	w.Close()
+
+	out, err := ioutil.ReadAll(r)
+	assert.NilError(t, err)
+
+	return string(out)
+}
+
+// assertSound checks that no solution value was eliminated from the grid
+func assertSound(t *testing.T, s *sudoku.Sudoku, solution string) {
+	t.Helper()
+
+	value := func(pos sudoku.Pos) int8 {
+		return int8(solution[int(pos.Row-1)*9+int(pos.Column-1)] - '0')
+	}
+
+	for _, c := range s.Solved {
+		if c.Value != 0 {
+			assert.Equal(t, value(c.Pos), c.Value, c.Pos)
+		}
+	}
+
+	seen := map[sudoku.Pos]bool{}
+	for _, c := range s.Candidates {
+		if c.Value == value(c.Pos) {
+			seen[c.Pos] = true
+		}
+	}
+
+	for _, c := range s.Solved {
+		if c.Value == 0 {
+			assert.Assert(t, seen[c.Pos], "solution value eliminated at %v", c.Pos)
+		}
+	}
+}
+
+// Strategies used to reach the state where a finder is tested
+var baseStrategies = []string{
+	"singles (simple)", "singles", "naked pairs", "naked triples",
+	"hidden pairs", "hidden triples", "naked quads", "hidden quads",
+	"pointing pairs", "box/line reduction", "x-wing", "y-wing",
+	"xyz-wing", "swordfish", "jellyfish",
+}
+
+// stalledHint solves the grid with the base strategies other than the
+// named one and returns the next step of the named strategy, checked
+// against the solution
+func stalledHint(t *testing.T, grid, name string) sudoku.Step {
+	t.Helper()
+
+	names := []string{}
+	for _, n := range baseStrategies {
+		if n != name {
+			names = append(names, n)
+		}
+	}
+
+	s, err := sudoku.NewSudoku(grid)
+	assert.NilError(t, err)
+
+	solution, ok := s.Solution()
+	assert.Assert(t, ok)
+
+	res, err := s.SolveContext(context.Background(), sudoku.SolveOptions{Strategies: names})
+	assert.NilError(t, err)
+	assert.Equal(t, sudoku.StatusStalled, res.Status)
+
+	step, ok, err := s.HintWith([]string{name})
+	assert.NilError(t, err)
+	assert.Assert(t, ok, "%s not found in %s", name, grid)
+	assert.Equal(t, name, step.Strategy)
+
+	value := func(c sudoku.Cell) int8 {
+		return int8(solution[int(c.Pos.Row-1)*9+int(c.Pos.Column-1)] - '0')
+	}
+
+	for _, c := range step.Solved {
+		assert.Equal(t, value(c), c.Value, c.Pos)
+	}
+	for _, c := range step.Eliminated {
+		assert.Assert(t, value(c) != c.Value, "solution value eliminated at %v", c.Pos)
+	}
+
+	return step
+}
+
+// copyNames returns a copy of the strategy names, for appending to
+func copyNames(names []string) []string {
+	return append([]string{}, names...)
+}
@@ -29,9 +29,9 @@ type DefaultLogger struct {
 }
 
 func (l DefaultLogger) Eliminated(strategy string, cells ...Cell) {
-	fmt.Println("Solved by", strategy, ":", cells)
+	fmt.Println("Eliminated by", strategy, ":", cells)
 }
 
 func (l DefaultLogger) Solved(strategy string, cells ...Cell) {
-	fmt.Println("Eliminated by", strategy, ":", cells)
+	fmt.Println("Solved by", strategy, ":", cells)
 }
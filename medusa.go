@@ -0,0 +1,229 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"math/bits"
+)
+
+// Candidates are numbered by cell index and number
+const medusaNodes = sudokuGridSize * (sudokuNumbers + 1)
+
+func candidateNode(c Cell) int {
+	return c.Pos.index()*(sudokuNumbers+1) + int(c.Value)
+}
+
+func nodeCell(node int) Cell {
+	return Cell{Pos: indexPos(node / (sudokuNumbers + 1)), Value: int8(node % (sudokuNumbers + 1))}
+}
+
+// medusaLinks returns the strong links between candidates: conjugate
+// pairs of a number and the two candidates of bivalue cells.
+func (s *Sudoku) medusaLinks() [][]int {
+	links := make([][]int, medusaNodes)
+
+	link := func(a, b Cell) {
+		na, nb := candidateNode(a), candidateNode(b)
+		links[na] = append(links[na], nb)
+		links[nb] = append(links[nb], na)
+	}
+
+	for _, pairs := range s.conjugatePairs() {
+		for _, p := range pairs {
+			link(p.a, p.b)
+		}
+	}
+
+	cands := s.cellCandidates(2, 2)
+	for _, pos := range sortedPositions(cands) {
+		nums := cands[pos]
+		link(Cell{Pos: pos, Value: nums[0]}, Cell{Pos: pos, Value: nums[1]})
+	}
+
+	return links
+}
+
+// medusaCluster colors the candidates linked to start alternately with
+// colors 1 and 2. Returns false if the links do not alternate.
+func medusaCluster(links [][]int, colors []int8, start int) ([]int, bool) {
+	cluster := []int{start}
+	colors[start] = 1
+	ok := true
+
+	for i := 0; i < len(cluster); i++ {
+		node := cluster[i]
+
+		for _, next := range links[node] {
+			switch colors[next] {
+			case 0:
+				colors[next] = 3 - colors[node]
+				cluster = append(cluster, next)
+			case colors[node]:
+				ok = false
+			}
+		}
+	}
+
+	return cluster, ok
+}
+
+// medusaColoring holds the candidates of a cluster by color, index 0 is
+// unused.
+type medusaColoring struct {
+	cells  [3][sudokuGridSize]uint16
+	digits [3][sudokuNumbers + 1]cellSet
+}
+
+func newMedusaColoring(cluster []int, colors []int8) *medusaColoring {
+	mc := &medusaColoring{}
+
+	for _, node := range cluster {
+		c := nodeCell(node)
+		idx := c.Pos.index()
+
+		mc.cells[colors[node]][idx] |= 1 << uint(c.Value)
+		mc.digits[colors[node]][c.Value].add(idx)
+	}
+
+	return mc
+}
+
+// sees tells if the candidate n of the cell idx sees a candidate n of the
+// color
+func (mc *medusaColoring) sees(color int8, idx int, n int8) bool {
+	return !peerSets[idx].and(mc.digits[color][n]).empty()
+}
+
+// isFalse tells if the color leads to a contradiction: it has two
+// candidates in a cell (rule 1) or a number twice in a house (rule 2), or
+// it removes all candidates of an uncolored cell (rule 6).
+func (mc *medusaColoring) isFalse(color int8, masks *[sudokuGridSize]uint16) bool {
+	for idx, mask := range masks {
+		if bits.OnesCount16(mc.cells[color][idx]) > 1 {
+			return true
+		}
+
+		if mask == 0 || mc.cells[1][idx]|mc.cells[2][idx] != 0 {
+			continue
+		}
+
+		emptied := true
+		for n := int8(1); n <= sudokuNumbers && emptied; n++ {
+			if mask&(1<<uint(n)) != 0 && !mc.sees(color, idx, n) {
+				emptied = false
+			}
+		}
+
+		if emptied {
+			return true
+		}
+	}
+
+	for n := int8(1); n <= sudokuNumbers; n++ {
+		for _, idx := range mc.digits[color][n].indexes() {
+			if mc.sees(color, idx, n) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// uncolored returns the uncolored candidates that are false whichever
+// color is true: ones in a cell with both colors (rule 3), seeing both
+// colors (rule 4), or seeing one color while the cell has the other
+// (rule 5).
+func (mc *medusaColoring) uncolored(masks *[sudokuGridSize]uint16) CellList {
+	found := CellList{}
+
+	for idx, mask := range masks {
+		in1, in2 := mc.cells[1][idx] != 0, mc.cells[2][idx] != 0
+
+		for n := int8(1); n <= sudokuNumbers; n++ {
+			bit := uint16(1) << uint(n)
+
+			if mask&bit == 0 || (mc.cells[1][idx]|mc.cells[2][idx])&bit != 0 {
+				continue
+			}
+
+			sees1, sees2 := mc.sees(1, idx, n), mc.sees(2, idx, n)
+
+			if (in1 && in2) || (sees1 && sees2) || (in1 && sees2) || (in2 && sees1) {
+				found = append(found, Cell{Pos: indexPos(idx), Value: n})
+			}
+		}
+	}
+
+	return found
+}
+
+// find3DMedusa colors candidates across numbers along conjugate pairs and
+// bivalue cells, in each cluster exactly one of the two colors is true.
+// A color leading to a contradiction is eliminated, otherwise uncolored
+// candidates that are false with either color are. The color classes of
+// the cluster are reported in Colors.
+func (s *Sudoku) find3DMedusa() finderResult {
+	none := finderResult{Solved: nil, Eliminated: nil}
+
+	masks := candidateMasks(s.Candidates)
+	links := s.medusaLinks()
+	colors := make([]int8, medusaNodes)
+
+	for _, start := range s.Candidates {
+		node := candidateNode(start)
+		if colors[node] != 0 || len(links[node]) == 0 {
+			continue
+		}
+
+		cluster, ok := medusaCluster(links, colors, node)
+		if !ok {
+			continue
+		}
+
+		mc := newMedusaColoring(cluster, colors)
+		classes := []CellList{{}, {}}
+
+		for _, c := range s.Candidates {
+			node := candidateNode(c)
+			if colors[node] != 0 && mc.cells[colors[node]][c.Pos.index()]&(1<<uint(c.Value)) != 0 {
+				classes[colors[node]-1] = append(classes[colors[node]-1], c)
+			}
+		}
+
+		found := CellList{}
+
+		for color := int8(1); color <= 2; color++ {
+			if mc.isFalse(color, &masks) {
+				found = classes[color-1]
+				break
+			}
+		}
+
+		if len(found) == 0 {
+			found = mc.uncolored(&masks)
+		}
+
+		if len(found) > 0 {
+			pattern := uniqueCells(append(copyCells(classes[0]), classes[1]...))
+
+			return finderResult{Solved: nil, Eliminated: found, Pattern: pattern, Colors: classes}
+		}
+	}
+
+	return none
+}
@@ -0,0 +1,95 @@
+package sudoku_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+func TestMedusa(t *testing.T) {
+	names := baseStrategies
+
+	tests := []struct {
+		grid       string
+		eliminated sudoku.CellList
+		colors     []sudoku.CellList
+	}{
+		// r3c3 sees 1 of both colors, the colors are linked through
+		// bivalue r6c4 (17)
+		{"000008010030900000640000000050000801800500004000002306010300007000465000200010000",
+			sudoku.CellList{cell(3, 3, 1, 1)},
+			[]sudoku.CellList{
+				{cell(3, 4, 2, 1), cell(5, 6, 5, 1), cell(6, 4, 5, 7)},
+				{cell(5, 3, 4, 1), cell(6, 4, 5, 1)}}},
+		// 9 is twice in row 6 with the first color
+		{"061070000040800000800000000500000092000007008000610070300000860400090007020500030",
+			sudoku.CellList{cell(1, 1, 1, 2), cell(1, 4, 2, 9), cell(5, 4, 5, 2), cell(6, 1, 4, 9), cell(6, 6, 5, 9)},
+			[]sudoku.CellList{
+				{cell(1, 1, 1, 2), cell(1, 4, 2, 9), cell(5, 4, 5, 2), cell(6, 1, 4, 9), cell(6, 6, 5, 9)},
+				{cell(1, 1, 1, 9), cell(1, 4, 2, 2), cell(5, 4, 5, 9), cell(6, 1, 4, 2)}}},
+	}
+
+	for _, test := range tests {
+		s, err := sudoku.NewSudoku(test.grid)
+		assert.NilError(t, err)
+
+		res, err := s.SolveContext(context.Background(), sudoku.SolveOptions{Strategies: names})
+		assert.NilError(t, err)
+		assert.Equal(t, sudoku.StatusStalled, res.Status)
+
+		step, ok, err := s.HintWith([]string{"3d medusa"})
+		assert.NilError(t, err)
+		assert.Assert(t, ok)
+		assert.DeepEqual(t, test.eliminated, step.Eliminated)
+		assert.DeepEqual(t, test.colors, step.Colors)
+		assert.Equal(t, len(step.Colors[0])+len(step.Colors[1]), len(step.Pattern))
+
+		solution, ok := s.Solution()
+		assert.Assert(t, ok)
+
+		assert.NilError(t, s.ApplyStep(step))
+		assertSound(t, s, solution)
+
+		_, err = s.SolveContext(context.Background(), sudoku.SolveOptions{Strategies: sudoku.AllStrategies()})
+		assert.NilError(t, err)
+		assertSound(t, s, solution)
+	}
+}
+
+func TestMedusaTrace(t *testing.T) {
+	s, err := sudoku.NewSudoku("061070000040800000800000000500000092000007008000610070300000860400090007020500030")
+	assert.NilError(t, err)
+
+	trace := &sudoku.Trace{}
+	s.SetTrace(trace)
+
+	_, err = s.SolveContext(context.Background(), sudoku.SolveOptions{
+		Strategies: append(copyNames(baseStrategies), "3d medusa"),
+	})
+	assert.NilError(t, err)
+
+	var buf strings.Builder
+	assert.NilError(t, trace.WriteJSON(&buf))
+
+	read, err := sudoku.ReadTrace(strings.NewReader(buf.String()))
+	assert.NilError(t, err)
+
+	found := false
+	for _, step := range read.Steps {
+		if step.Strategy == "3d medusa" {
+			found = true
+			assert.Equal(t, 2, len(step.Colors))
+		} else {
+			assert.Assert(t, step.Colors == nil, step.Strategy)
+		}
+	}
+	assert.Assert(t, found)
+
+	data, err := json.Marshal(read.Steps[0])
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(string(data), "colors"))
+}
@@ -34,6 +34,10 @@ func (p Pos) init(row int8, col int8) Pos {
 	return p
 }
 
+func (p Pos) index() int {
+	return int(p.Row-1)*sudokuNumbers + int(p.Column-1)
+}
+
 func (p Pos) eqRow(other Pos) bool {
 	return p.Row == other.Row
 }
@@ -0,0 +1,110 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"context"
+)
+
+// Rating describes the difficulty of a puzzle by the hardest step
+// Solve needed for it.
+type Rating struct {
+	Status SolveStatus `json:"status"`
+	Steps  int         `json:"steps"`
+	// Position of the strategy of the hardest step in solver order,
+	// starting from 1
+	Level   int    `json:"level"`
+	Hardest string `json:"hardest,omitempty"`
+	// Sudoku Explainer ratings: the hardest step, the hardest step up to
+	// the first placement and the first step. They are comparable to the
+	// ratings of Sudoku Explainer only for a path of simplest steps, as
+	// Rate takes, otherwise they rate the path the solver took.
+	ER float64 `json:"er"`
+	EP float64 `json:"ep"`
+	ED float64 `json:"ed"`
+}
+
+// Rating rates the steps recorded in the trace.
+func (t *Trace) Rating() Rating {
+	r := Rating{Steps: len(t.Steps)}
+	placed := false
+
+	for i, step := range t.Steps {
+		d := step.SEDifficulty()
+		if i == 0 {
+			r.ED = d
+		}
+		if i == 0 || d > r.ER {
+			r.ER = d
+			r.Hardest = step.Strategy
+			r.Level = strategyIndex(step.Strategy) + 1
+		}
+		if !placed {
+			r.EP = r.ER
+			placed = len(step.Solved) > 0
+		}
+	}
+
+	return r
+}
+
+// Rate solves the grid applying the simplest step at a time, like Sudoku
+// Explainer, and rates it. All the strategies with a Sudoku Explainer
+// rating are used, optional ones included.
+func Rate(grid string) (Rating, error) {
+	return RateContext(context.Background(), grid)
+}
+
+// RateContext is like Rate, but stops solving when ctx is done.
+func RateContext(ctx context.Context, grid string) (Rating, error) {
+	s, err := NewSudoku(grid)
+	if err != nil {
+		return Rating{}, err
+	}
+
+	return s.rate(ctx, SolveOptions{Strategies: ratedStrategies(), Simplest: true})
+}
+
+// ratedStrategies returns the strategies that have a Sudoku Explainer
+// rating, in solver order.
+func ratedStrategies() []string {
+	names := []string{}
+
+	for _, st := range strategies {
+		if _, ok := seWeights[st.name]; ok {
+			names = append(names, st.name)
+		}
+	}
+
+	return names
+}
+
+func (s *Sudoku) rate(ctx context.Context, opts SolveOptions) (Rating, error) {
+	trace := &Trace{}
+	s.SetTrace(trace)
+	defer s.SetTrace(nil)
+
+	res, err := s.SolveContext(ctx, opts)
+	if err != nil {
+		return Rating{}, err
+	}
+
+	r := trace.Rating()
+	r.Status = res.Status
+
+	return r, nil
+}
@@ -0,0 +1,288 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GridLayout selects how WriteGrid lays out the grid.
+type GridLayout int
+
+const (
+	// LayoutCompact writes the grid as a single 81 character line.
+	LayoutCompact GridLayout = iota
+	// LayoutASCII writes the grid with boxes drawn using +, - and |.
+	LayoutASCII
+	// LayoutUnicode writes the grid with boxes drawn using box-drawing characters.
+	LayoutUnicode
+	// LayoutCandidates writes every unsolved cell as a 3x3 block of candidates.
+	LayoutCandidates
+	// LayoutFramed writes the grid inside a single frame without box
+	// separators, as Sudoku.PrintGrid does.
+	LayoutFramed
+	// LayoutRows writes the grid as nine lines of space separated values,
+	// as PrintGrid does.
+	LayoutRows
+)
+
+// GridStyle controls the output of WriteGrid.
+type GridStyle struct {
+	Layout GridLayout
+	// Color highlights givens and deduced values with ANSI escapes.
+	Color bool
+	// Grid the values were deduced from, used by Color to tell the
+	// givens. Without it the givens of the sudoku are used.
+	Givens string
+}
+
+const (
+	ansiReset   = "\x1b[0m"
+	ansiGiven   = "\x1b[1m"
+	ansiDeduced = "\x1b[36m"
+)
+
+type gridBorders struct {
+	top, middle, bottom string
+	vertical            string
+	// Draw the separators between boxes
+	boxes bool
+}
+
+var asciiBorders = gridBorders{
+	top:      "+-------+-------+-------+",
+	middle:   "+-------+-------+-------+",
+	bottom:   "+-------+-------+-------+",
+	vertical: "|",
+	boxes:    true,
+}
+
+var unicodeBorders = gridBorders{
+	top:      "┌───────┬───────┬───────┐",
+	middle:   "├───────┼───────┼───────┤",
+	bottom:   "└───────┴───────┴───────┘",
+	vertical: "│",
+	boxes:    true,
+}
+
+var framedBorders = gridBorders{
+	top:      "+-------------------+",
+	bottom:   "+-------------------+",
+	vertical: "|",
+}
+
+var candidateBorders = gridBorders{
+	top:      "+-------------+-------------+-------------+",
+	middle:   "+-------------+-------------+-------------+",
+	bottom:   "+-------------+-------------+-------------+",
+	vertical: "|",
+	boxes:    true,
+}
+
+// WriteGrid writes the grid string to w using the given style.
+func WriteGrid(w io.Writer, grid string, style GridStyle) error {
+	s, err := NewSudoku(grid)
+	if err != nil {
+		return err
+	}
+
+	return s.WriteGrid(w, style)
+}
+
+// WriteGrid writes the current state of the sudoku to w using the given style.
+func (s Sudoku) WriteGrid(w io.Writer, style GridStyle) error {
+	bw := bufio.NewWriter(w)
+
+	switch style.Layout {
+	case LayoutASCII:
+		s.writeBoxed(bw, asciiBorders, style)
+	case LayoutUnicode:
+		s.writeBoxed(bw, unicodeBorders, style)
+	case LayoutCandidates:
+		s.writeCandidates(bw, candidateBorders, style)
+	case LayoutFramed:
+		s.writeBoxed(bw, framedBorders, style)
+	case LayoutRows:
+		s.writeRows(bw, style)
+	default:
+		s.writeCompact(bw, style)
+	}
+
+	return bw.Flush()
+}
+
+func (s Sudoku) isGiven(pos Pos, style GridStyle) bool {
+	idx := (pos.Row-1)*sudokuNumbers + (pos.Column - 1)
+
+	if len(style.Givens) != sudokuGridSize {
+		return s.givens[idx]
+	}
+
+	c := style.Givens[idx]
+	return c >= '1' && c <= '9'
+}
+
+// Empty cells are written as zeros
+func (s Sudoku) valueString(cell Cell, style GridStyle) string {
+	if cell.Value == 0 {
+		return "0"
+	}
+
+	return s.cellString(cell, style)
+}
+
+func (s Sudoku) cellString(cell Cell, style GridStyle) string {
+	if cell.Value == 0 {
+		return "."
+	}
+
+	str := string('0' + rune(cell.Value))
+	if !style.Color {
+		return str
+	}
+
+	if s.isGiven(cell.Pos, style) {
+		return ansiGiven + str + ansiReset
+	}
+	return ansiDeduced + str + ansiReset
+}
+
+func (s Sudoku) writeCompact(w *bufio.Writer, style GridStyle) {
+	for _, cell := range s.Solved {
+		w.WriteString(s.valueString(cell, style))
+	}
+	w.WriteByte('\n')
+}
+
+func (s Sudoku) writeRows(w *bufio.Writer, style GridStyle) {
+	for i, cell := range s.Solved {
+		w.WriteString(s.valueString(cell, style))
+
+		if (i+1)%sudokuNumbers == 0 {
+			w.WriteByte('\n')
+		} else {
+			w.WriteByte(' ')
+		}
+	}
+}
+
+// writeGridRows writes the runes of grid like LayoutRows. Only the size
+// of the grid is checked.
+func writeGridRows(w io.Writer, grid string) error {
+	if len(grid) != sudokuGridSize {
+		return fmt.Errorf("Grid has invalid size '%d'", len(grid))
+	}
+
+	bw := bufio.NewWriter(w)
+
+	for i, c := range grid {
+		bw.WriteRune(c)
+
+		if (i+1)%sudokuNumbers == 0 {
+			bw.WriteByte('\n')
+		} else {
+			bw.WriteByte(' ')
+		}
+	}
+
+	return bw.Flush()
+}
+
+func (s Sudoku) writeBoxed(w *bufio.Writer, b gridBorders, style GridStyle) {
+	w.WriteString(b.top + "\n")
+
+	for i, cell := range s.Solved {
+		col := i % sudokuNumbers
+
+		if col == 0 || (b.boxes && col%sudokuBoxes == 0) {
+			w.WriteString(b.vertical + " ")
+		}
+
+		w.WriteString(s.cellString(cell, style))
+		w.WriteByte(' ')
+
+		if col == sudokuNumbers-1 {
+			w.WriteString(b.vertical + "\n")
+
+			row := i / sudokuNumbers
+			if row == sudokuNumbers-1 {
+				w.WriteString(b.bottom + "\n")
+			} else if b.boxes && row%sudokuBoxes == sudokuBoxes-1 {
+				w.WriteString(b.middle + "\n")
+			}
+		}
+	}
+}
+
+// Each unsolved cell is drawn as three rows of three candidates, with
+// missing candidates shown as dots. Solved cells show only their value
+// in the middle so that they cannot be confused with a single candidate.
+func (s Sudoku) writeCandidates(w *bufio.Writer, b gridBorders, style GridStyle) {
+	var cands [sudokuGridSize][sudokuNumbers + 1]bool
+
+	for _, c := range s.Candidates {
+		cands[(c.Pos.Row-1)*sudokuNumbers+(c.Pos.Column-1)][c.Value] = true
+	}
+
+	w.WriteString(b.top + "\n")
+
+	for row := 0; row < sudokuNumbers; row++ {
+		for sub := 0; sub < sudokuBoxes; sub++ {
+			var line strings.Builder
+
+			for col := 0; col < sudokuNumbers; col++ {
+				if col%sudokuBoxes == 0 {
+					line.WriteString(b.vertical + " ")
+				}
+
+				idx := row*sudokuNumbers + col
+				cell := s.Solved[idx]
+
+				if cell.Value != 0 {
+					if sub == 1 {
+						line.WriteString(" " + s.cellString(cell, style) + " ")
+					} else {
+						line.WriteString("   ")
+					}
+				} else {
+					for k := 1; k <= sudokuBoxes; k++ {
+						n := sub*sudokuBoxes + k
+						if cands[idx][n] {
+							line.WriteRune('0' + rune(n))
+						} else {
+							line.WriteByte('.')
+						}
+					}
+				}
+
+				line.WriteByte(' ')
+			}
+
+			line.WriteString(b.vertical + "\n")
+			w.WriteString(line.String())
+		}
+
+		if row == sudokuNumbers-1 {
+			w.WriteString(b.bottom + "\n")
+		} else if row%sudokuBoxes == sudokuBoxes-1 {
+			w.WriteString(b.middle + "\n")
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package sudoku_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+func TestWriteGridCompact(t *testing.T) {
+	grid := "000921003009000060000000500080403006007000800500700040003000000020000700800195000"
+
+	var buf bytes.Buffer
+	err := sudoku.WriteGrid(&buf, grid, sudoku.GridStyle{Layout: sudoku.LayoutCompact})
+	assert.NilError(t, err)
+	assert.Equal(t, grid+"\n", buf.String())
+}
+
+func TestWriteGridLayouts(t *testing.T) {
+	grid := "000040700500780020070002006810007900460000051009600078900800010080064009002050000"
+
+	s, err := sudoku.NewSudoku(grid)
+	assert.NilError(t, err)
+
+	layouts := []struct {
+		layout sudoku.GridLayout
+		lines  int
+		first  string
+	}{
+		{sudoku.LayoutASCII, 13, "+-------+-------+-------+"},
+		{sudoku.LayoutUnicode, 13, "┌───────┬───────┬───────┐"},
+		{sudoku.LayoutCandidates, 31, "+-------------+-------------+-------------+"},
+	}
+
+	for _, l := range layouts {
+		var buf bytes.Buffer
+		err := s.WriteGrid(&buf, sudoku.GridStyle{Layout: l.layout})
+		assert.NilError(t, err)
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		assert.Equal(t, l.lines, len(lines))
+		assert.Equal(t, l.first, lines[0])
+	}
+
+	var buf bytes.Buffer
+	err = s.WriteGrid(&buf, sudoku.GridStyle{Layout: sudoku.LayoutASCII})
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(buf.String(), "| . . . | . 4 . | 7 . . |"))
+}
+
+func TestPrintGridFormat(t *testing.T) {
+	grid := "000040700500780020070002006810007900460000051009600078900800010080064009002050000"
+
+	s, err := sudoku.NewSudoku(grid)
+	assert.NilError(t, err)
+
+	out := captureStdout(t, s.PrintGrid)
+	assert.Equal(t, `+-------------------+
+| . . . . 4 . 7 . . |
+| 5 . . 7 8 . . 2 . |
+| . 7 . . . 2 . . 6 |
+| 8 1 . . . 7 9 . . |
+| 4 6 . . . . . 5 1 |
+| . . 9 6 . . . 7 8 |
+| 9 . . 8 . . . 1 . |
+| . 8 . . 6 4 . . 9 |
+| . . 2 . 5 . . . . |
++-------------------+
+`, out)
+
+	var buf bytes.Buffer
+	assert.NilError(t, s.WriteGrid(&buf, sudoku.GridStyle{Layout: sudoku.LayoutFramed}))
+	assert.Equal(t, out, buf.String())
+
+	rows := `0 0 0 0 4 0 7 0 0
+5 0 0 7 8 0 0 2 0
+0 7 0 0 0 2 0 0 6
+8 1 0 0 0 7 9 0 0
+4 6 0 0 0 0 0 5 1
+0 0 9 6 0 0 0 7 8
+9 0 0 8 0 0 0 1 0
+0 8 0 0 6 4 0 0 9
+0 0 2 0 5 0 0 0 0
+`
+	out = captureStdout(t, func() {
+		assert.NilError(t, sudoku.PrintGrid(grid))
+	})
+	assert.Equal(t, rows, out)
+
+	buf.Reset()
+	assert.NilError(t, s.WriteGrid(&buf, sudoku.GridStyle{Layout: sudoku.LayoutRows}))
+	assert.Equal(t, rows, buf.String())
+
+	// The runes are printed as they are
+	dotted := strings.Replace(grid, "0", ".", -1)
+	out = captureStdout(t, func() {
+		assert.NilError(t, sudoku.PrintGrid("X"+dotted[1:]))
+	})
+	assert.Equal(t, "X"+strings.Replace(rows, "0", ".", -1)[1:], out)
+}
+
+func TestWriteGridColor(t *testing.T) {
+	grid := "000040700500780020070002006810007900460000051009600078900800010080064009002050000"
+
+	s, err := sudoku.NewSudoku(grid)
+	assert.NilError(t, err)
+	assert.Assert(t, s.Solve())
+
+	var buf bytes.Buffer
+	err = s.WriteGrid(&buf, sudoku.GridStyle{Layout: sudoku.LayoutCompact, Color: true, Givens: grid})
+	assert.NilError(t, err)
+
+	out := buf.String()
+	assert.Assert(t, strings.HasPrefix(out, "\x1b[36m6\x1b[0m"))
+	assert.Assert(t, strings.Contains(out, "\x1b[1m4\x1b[0m"))
+
+	// The sudoku knows its givens
+	buf.Reset()
+	err = s.WriteGrid(&buf, sudoku.GridStyle{Layout: sudoku.LayoutCompact, Color: true})
+	assert.NilError(t, err)
+	assert.Equal(t, out, buf.String())
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"math"
+)
+
+// Difficulties of the strategies on the Sudoku Explainer scale, before
+// adjustments for the size of the pattern
+var seWeights = map[string]float64{
+	"singles (simple)":         2.3,
+	"singles":                  1.5,
+	"pointing pairs":           2.6,
+	"box/line reduction":       2.8,
+	"naked pairs":              3.0,
+	"x-wing":                   3.2,
+	"hidden pairs":             3.4,
+	"naked triples":            3.6,
+	"swordfish":                3.8,
+	"hidden triples":           4.0,
+	"y-wing":                   4.2,
+	"xyz-wing":                 4.4,
+	"naked quads":              5.0,
+	"jellyfish":                5.2,
+	"hidden quads":             5.4,
+	"bug+1":                    5.6,
+	"bug+n":                    5.7,
+	"aligned pair exclusion":   6.2,
+	"aligned triple exclusion": 7.5,
+	"nishio":                   7.5,
+	"cell forcing chain":       8.2,
+	"region forcing chain":     8.3,
+	"digit forcing chain":      8.5,
+
+	// Local extensions: Sudoku Explainer does not have these, the
+	// weights place them next to the techniques of similar difficulty
+	"w-wing":                    4.4,
+	"skyscraper":                4.0,
+	"2-string kite":             4.1,
+	"turbot fish":               4.2,
+	"empty rectangle":           4.5,
+	"wxyz-wing":                 4.6,
+	"finned x-wing":             3.4,
+	"finned swordfish":          4.0,
+	"finned jellyfish":          5.4,
+	"template":                  4.8,
+	"sue de coq":                5.0,
+	"als-xz":                    5.5,
+	"als-xy-wing":               6.0,
+	"death blossom":             6.5,
+	"avoidable rectangle":       4.5,
+	"extended unique rectangle": 4.6,
+	"3d medusa":                 6.5,
+	"junior exocet":             7.0,
+}
+
+// Strategies rated by the length of the chain or the number of cells in
+// the pattern
+var seLengthAdjusted = map[string]bool{
+	"nishio": true, "sue de coq": true, "als-xz": true, "als-xy-wing": true,
+	"death blossom": true, "bug+n": true, "3d medusa": true,
+	"cell forcing chain": true, "region forcing chain": true, "digit forcing chain": true,
+}
+
+// seLengthDifficulty is the increase for a chain of the given length as
+// in Sudoku Explainer: 0.1 for each step of the bounds 4, 6, 8, 12, 16...
+// the length exceeds.
+func seLengthDifficulty(length int) float64 {
+	added := 0.0
+	ceil := 4
+	odd := false
+
+	for length-2 > ceil {
+		added += 0.1
+
+		if odd {
+			ceil = ceil * 4 / 3
+		} else {
+			ceil = ceil * 3 / 2
+		}
+		odd = !odd
+	}
+
+	return added
+}
+
+// seLength is the number of nodes in the chain of the step, or the cells
+// of the pattern.
+func (step Step) seLength() int {
+	if len(step.Chain) > 0 {
+		length := 0
+
+		for _, branch := range step.Chain {
+			length++
+			for _, link := range branch.Links {
+				length += len(link.Solved) + len(link.Eliminated)
+			}
+		}
+
+		return length
+	}
+
+	if len(step.Colors) > 0 {
+		return len(step.Pattern)
+	}
+
+	return len(ucpos(step.Pattern))
+}
+
+// SEDifficulty returns the difficulty of the step on the Sudoku Explainer
+// scale, zero for an unknown strategy. Strategies that Sudoku Explainer
+// does not have get a local estimate.
+func (step Step) SEDifficulty() float64 {
+	d, ok := seWeights[step.Strategy]
+	if !ok {
+		return 0
+	}
+
+	if seLengthAdjusted[step.Strategy] {
+		d += seLengthDifficulty(step.seLength())
+	}
+
+	return math.Round(d*10) / 10
+}
@@ -0,0 +1,73 @@
+package sudoku_test
+
+import (
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+func TestSEDifficulty(t *testing.T) {
+	for _, name := range sudoku.AllStrategies() {
+		assert.Assert(t, sudoku.Step{Strategy: name}.SEDifficulty() > 0, name)
+	}
+	assert.Equal(t, 0.0, sudoku.Step{Strategy: "guessing"}.SEDifficulty())
+
+	// Longer chains are harder
+	chain := func(links int) sudoku.Step {
+		branch := sudoku.ForcingBranch{Assumption: cell(1, 1, 1, 1)}
+		for i := 0; i < links; i++ {
+			branch.Links = append(branch.Links, sudoku.ForcingLink{
+				Strategy: "singles", Solved: sudoku.CellList{cell(2, 2, 1, 2)},
+			})
+		}
+		return sudoku.Step{Strategy: "cell forcing chain", Chain: []sudoku.ForcingBranch{branch, branch}}
+	}
+
+	assert.Equal(t, 8.2, chain(1).SEDifficulty())
+	assert.Equal(t, 8.3, chain(3).SEDifficulty())
+	assert.Equal(t, 8.4, chain(4).SEDifficulty())
+	assert.Equal(t, 8.7, chain(10).SEDifficulty())
+}
+
+func TestSERating(t *testing.T) {
+	r, err := sudoku.Rate("000040700500780020070002006810007900460000051009600078900800010080064009002050000")
+	assert.NilError(t, err)
+	assert.Equal(t, 3.4, r.ER)
+	assert.Equal(t, "finned x-wing", r.Hardest)
+	// Hidden singles come before naked ones on the simplest path
+	assert.Equal(t, 1.5, r.EP)
+	assert.Equal(t, 1.5, r.ED)
+
+	// Golden Nugget is beyond the strategies, but the steps taken are rated
+	r, err = sudoku.Rate("000000039000001005003050800008090006070002000100400000009080050020000600400700000")
+	assert.NilError(t, err)
+	assert.Equal(t, sudoku.StatusStalled, r.Status)
+	assert.Equal(t, "junior exocet", r.Hardest)
+	assert.Equal(t, 7.0, r.ER)
+
+	trace := sudoku.Trace{Steps: []sudoku.Step{
+		{Strategy: "x-wing", Eliminated: sudoku.CellList{cell(1, 1, 1, 1)}},
+		{Strategy: "hidden pairs", Eliminated: sudoku.CellList{cell(1, 1, 1, 2)}},
+		{Strategy: "singles", Solved: sudoku.CellList{cell(1, 1, 1, 3)}},
+		{Strategy: "jellyfish", Eliminated: sudoku.CellList{cell(1, 2, 1, 1)}},
+	}}
+
+	r = trace.Rating()
+	assert.Equal(t, 5.2, r.ER)
+	assert.Equal(t, "jellyfish", r.Hardest)
+	assert.Equal(t, 3.4, r.EP)
+	assert.Equal(t, 3.2, r.ED)
+
+	// The hardest step is the one with the highest rating, not the last
+	// one in solver order
+	trace = sudoku.Trace{Steps: []sudoku.Step{
+		{Strategy: "hidden quads", Eliminated: sudoku.CellList{cell(1, 1, 1, 1)}},
+		{Strategy: "2-string kite", Eliminated: sudoku.CellList{cell(1, 1, 1, 2)}},
+	}}
+
+	r = trace.Rating()
+	assert.Equal(t, 5.4, r.ER)
+	assert.Equal(t, "hidden quads", r.Hardest)
+	assert.Equal(t, 8, r.Level)
+}
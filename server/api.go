@@ -0,0 +1,232 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/jjhoo/go-sudoku"
+)
+
+// Error is the structured error returned by every endpoint.
+type Error struct {
+	Status  int    `json:"-"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Set for grids of invalid size
+	Size int `json:"size,omitempty"`
+	// Set for invalid runes in a grid
+	Index *int   `json:"index,omitempty"`
+	Rune  string `json:"rune,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func newError(status int, code string, err error) *Error {
+	return &Error{Status: status, Code: code, Message: err.Error()}
+}
+
+// ErrorFrom converts errors returned by the API functions and the
+// sudoku package to an Error.
+func ErrorFrom(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+
+	var gerr *sudoku.GridError
+	if errors.As(err, &gerr) {
+		e = newError(http.StatusBadRequest, "invalid_grid", err)
+		if gerr.Index < 0 {
+			e.Size = gerr.Size
+		} else {
+			index := gerr.Index
+			e.Index = &index
+			e.Rune = string(gerr.Rune)
+		}
+		return e
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return newError(http.StatusServiceUnavailable, "timeout", err)
+	case errors.Is(err, context.Canceled):
+		return newError(http.StatusServiceUnavailable, "cancelled", err)
+	case errors.Is(err, sudoku.ErrNoPuzzle):
+		return newError(http.StatusServiceUnavailable, "no_puzzle", err)
+	}
+
+	return newError(http.StatusBadRequest, "invalid_request", err)
+}
+
+// GridRequest is the request of the endpoints that take only a grid.
+type GridRequest struct {
+	Grid string `json:"grid"`
+}
+
+type SolveRequest struct {
+	Grid       string   `json:"grid"`
+	Strategies []string `json:"strategies,omitempty"`
+	MaxSteps   int      `json:"max_steps,omitempty"`
+	// Apply the simplest available step at a time
+	Simplest bool `json:"simplest,omitempty"`
+	// Include the steps taken in the response
+	Trace bool `json:"trace,omitempty"`
+}
+
+type SolveResponse struct {
+	Status sudoku.SolveStatus `json:"status"`
+	Steps  int                `json:"steps"`
+	Grid   string             `json:"grid"`
+	Trace  []sudoku.Step      `json:"trace,omitempty"`
+	Reason string             `json:"reason,omitempty"`
+}
+
+type HintResponse struct {
+	Found bool         `json:"found"`
+	Step  *sudoku.Step `json:"step,omitempty"`
+}
+
+type GenerateRequest struct {
+	Seed      int64 `json:"seed,omitempty"`
+	Symmetric bool  `json:"symmetric,omitempty"`
+	Solvable  bool  `json:"solvable,omitempty"`
+}
+
+type GenerateResponse struct {
+	Grid   string        `json:"grid"`
+	Rating sudoku.Rating `json:"rating"`
+}
+
+type ValidateResponse struct {
+	Valid bool `json:"valid"`
+	// Number of solutions, at most 2
+	Solutions int    `json:"solutions"`
+	Unique    bool   `json:"unique"`
+	Error     *Error `json:"error,omitempty"`
+}
+
+type CanonicalResponse struct {
+	Canonical string `json:"canonical"`
+}
+
+func Solve(ctx context.Context, req SolveRequest) (SolveResponse, error) {
+	s, err := sudoku.NewSudoku(req.Grid)
+	if err != nil {
+		return SolveResponse{}, err
+	}
+
+	var trace *sudoku.Trace
+	if req.Trace {
+		trace = &sudoku.Trace{}
+		s.SetTrace(trace)
+	}
+
+	res, err := s.SolveContext(ctx, sudoku.SolveOptions{
+		Strategies: req.Strategies, MaxSteps: req.MaxSteps, Simplest: req.Simplest,
+	})
+	if err != nil {
+		return SolveResponse{}, err
+	}
+
+	resp := SolveResponse{Status: res.Status, Steps: res.Steps, Grid: s.GetGridString()}
+	if trace != nil {
+		resp.Trace = trace.Steps
+	}
+	if res.Err != nil {
+		resp.Reason = res.Err.Error()
+	}
+
+	return resp, nil
+}
+
+func Hint(ctx context.Context, req GridRequest) (HintResponse, error) {
+	s, err := sudoku.NewSudoku(req.Grid)
+	if err != nil {
+		return HintResponse{}, err
+	}
+
+	// The strategies of a hint can not be interrupted, give up before
+	// and after the search
+	if err := ctx.Err(); err != nil {
+		return HintResponse{}, err
+	}
+
+	step, ok := s.Hint()
+	if err := ctx.Err(); err != nil {
+		return HintResponse{}, err
+	}
+
+	if !ok {
+		return HintResponse{}, nil
+	}
+
+	return HintResponse{Found: true, Step: &step}, nil
+}
+
+func Rate(ctx context.Context, req GridRequest) (sudoku.Rating, error) {
+	return sudoku.RateContext(ctx, req.Grid)
+}
+
+func Generate(ctx context.Context, req GenerateRequest) (GenerateResponse, error) {
+	grid, err := sudoku.GenerateContext(ctx, sudoku.GenerateOptions{
+		Seed: req.Seed, Symmetric: req.Symmetric, Solvable: req.Solvable,
+	})
+	if err != nil {
+		return GenerateResponse{}, err
+	}
+
+	rating, err := sudoku.RateContext(ctx, grid)
+	if err != nil {
+		return GenerateResponse{}, err
+	}
+
+	return GenerateResponse{Grid: grid, Rating: rating}, nil
+}
+
+// Validate reports problems with the grid in the response rather than
+// as an error.
+func Validate(ctx context.Context, req GridRequest) (ValidateResponse, error) {
+	s, err := sudoku.NewSudoku(req.Grid)
+	if err != nil {
+		return ValidateResponse{Error: ErrorFrom(err)}, nil
+	}
+
+	if err := s.Validate(); err != nil {
+		return ValidateResponse{Error: newError(http.StatusBadRequest, "conflict", err)}, nil
+	}
+
+	sols, err := sudoku.SolutionsContext(ctx, req.Grid, 2)
+	if err != nil {
+		return ValidateResponse{}, err
+	}
+
+	return ValidateResponse{Valid: true, Solutions: len(sols), Unique: len(sols) == 1}, nil
+}
+
+func Canonical(ctx context.Context, req GridRequest) (CanonicalResponse, error) {
+	c, err := sudoku.Canonical(req.Grid)
+	if err != nil {
+		return CanonicalResponse{}, err
+	}
+
+	return CanonicalResponse{Canonical: c}, nil
+}
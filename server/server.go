@@ -0,0 +1,179 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+// Package server implements a JSON over HTTP API for solving, rating
+// and generating puzzles.
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// Options configure the handler returned by New.
+type Options struct {
+	// Maximum size of a request body in bytes, zero means 64 KiB
+	MaxBodyBytes int64
+	// Time limit of a request, zero means 10 seconds
+	Timeout time.Duration
+}
+
+type handler struct {
+	opts Options
+}
+
+type apiFunc func(ctx context.Context, r *http.Request) (interface{}, error)
+
+// New returns a handler serving the API endpoints.
+func New(opts Options) http.Handler {
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = 64 << 10
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+
+	h := handler{opts: opts}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/solve", h.wrap(func(ctx context.Context, r *http.Request) (interface{}, error) {
+		var req SolveRequest
+		if err := decode(r, &req); err != nil {
+			return nil, err
+		}
+		return Solve(ctx, req)
+	}))
+
+	mux.HandleFunc("/hint", h.wrap(func(ctx context.Context, r *http.Request) (interface{}, error) {
+		var req GridRequest
+		if err := decode(r, &req); err != nil {
+			return nil, err
+		}
+		return Hint(ctx, req)
+	}))
+
+	mux.HandleFunc("/rate", h.wrap(func(ctx context.Context, r *http.Request) (interface{}, error) {
+		var req GridRequest
+		if err := decode(r, &req); err != nil {
+			return nil, err
+		}
+		return Rate(ctx, req)
+	}))
+
+	mux.HandleFunc("/generate", h.wrap(func(ctx context.Context, r *http.Request) (interface{}, error) {
+		var req GenerateRequest
+		if err := decode(r, &req); err != nil {
+			return nil, err
+		}
+		return Generate(ctx, req)
+	}))
+
+	mux.HandleFunc("/validate", h.wrap(func(ctx context.Context, r *http.Request) (interface{}, error) {
+		var req GridRequest
+		if err := decode(r, &req); err != nil {
+			return nil, err
+		}
+		return Validate(ctx, req)
+	}))
+
+	mux.HandleFunc("/canonical", h.wrap(func(ctx context.Context, r *http.Request) (interface{}, error) {
+		var req GridRequest
+		if err := decode(r, &req); err != nil {
+			return nil, err
+		}
+		return Canonical(ctx, req)
+	}))
+
+	return mux
+}
+
+func decode(r *http.Request, v interface{}) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(v); err != nil {
+		return newError(http.StatusBadRequest, "invalid_json", err)
+	}
+
+	return nil
+}
+
+// readBody reads the request body into memory, failing if it is longer
+// than limit bytes.
+func readBody(r *http.Request, limit int64) error {
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, limit+1))
+	if err != nil {
+		return newError(http.StatusBadRequest, "invalid_json", err)
+	}
+
+	if int64(len(body)) > limit {
+		return newError(http.StatusRequestEntityTooLarge, "request_too_large",
+			fmt.Errorf("Request body is larger than %d bytes", limit))
+	}
+
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return nil
+}
+
+func (h handler) wrap(fun apiFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, newError(http.StatusMethodNotAllowed, "method_not_allowed",
+				fmt.Errorf("Method %s not allowed", r.Method)))
+			return
+		}
+
+		if err := readBody(r, h.opts.MaxBodyBytes); err != nil {
+			writeError(w, ErrorFrom(err))
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), h.opts.Timeout)
+		defer cancel()
+
+		resp, err := fun(ctx, r)
+		if err == nil {
+			err = ctx.Err()
+		}
+
+		if err != nil {
+			writeError(w, ErrorFrom(err))
+			return
+		}
+
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, e *Error) {
+	writeJSON(w, e.Status, struct {
+		Error *Error `json:"error"`
+	}{e})
+}
@@ -0,0 +1,149 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jjhoo/go-sudoku/server"
+	"gotest.tools/v3/assert"
+)
+
+func post(t *testing.T, h http.Handler, path, body string) (int, map[string]interface{}) {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	h.ServeHTTP(rec, req)
+
+	var resp map[string]interface{}
+	assert.NilError(t, json.Unmarshal(rec.Body.Bytes(), &resp), rec.Body.String())
+
+	return rec.Code, resp
+}
+
+func TestSolve(t *testing.T) {
+	h := server.New(server.Options{})
+
+	code, resp := post(t, h, "/solve",
+		`{"grid": "000040700500780020070002006810007900460000051009600078900800010080064009002050000", "trace": true}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "solved", resp["status"])
+	assert.Equal(t, "628341795594786123173592846815437962467928351239615478956873214781264539342159687", resp["grid"])
+	assert.Assert(t, len(resp["trace"].([]interface{})) > 0)
+
+	code, resp = post(t, h, "/solve",
+		`{"grid": "000040700500780020070002006810007900460000051009600078900800010080064009002050000", "max_steps": 2}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "cancelled", resp["status"])
+	assert.Equal(t, "Step limit reached", resp["reason"])
+}
+
+func TestHintAndRate(t *testing.T) {
+	h := server.New(server.Options{})
+	grid := `{"grid": "000704005020010070000080002090006250600070008053200010400090000030060090200407000"}`
+
+	code, resp := post(t, h, "/hint", grid)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, true, resp["found"])
+	step := resp["step"].(map[string]interface{})
+	assert.Equal(t, "singles (simple)", step["strategy"])
+
+	code, resp = post(t, h, "/rate", grid)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "solved", resp["status"])
+	assert.Equal(t, "sue de coq", resp["hardest"])
+	assert.Equal(t, 5.0, resp["er"])
+}
+
+func TestGenerateValidateCanonical(t *testing.T) {
+	h := server.New(server.Options{})
+
+	code, resp := post(t, h, "/generate", `{"seed": 42, "symmetric": true}`)
+	assert.Equal(t, http.StatusOK, code)
+	grid := resp["grid"].(string)
+	assert.Equal(t, 81, len(grid))
+
+	for i := 0; i < 81; i++ {
+		assert.Equal(t, grid[i] == '0', grid[80-i] == '0')
+	}
+
+	body, _ := json.Marshal(map[string]string{"grid": grid})
+
+	code, resp = post(t, h, "/validate", string(body))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, true, resp["valid"])
+	assert.Equal(t, true, resp["unique"])
+
+	code, resp = post(t, h, "/validate",
+		`{"grid": "110040700500780020070002006810007900460000051009600078900800010080064009002050000"}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, false, resp["valid"])
+	assert.Equal(t, "conflict", resp["error"].(map[string]interface{})["code"])
+
+	code, resp = post(t, h, "/canonical",
+		`{"grid": "000040700500780020070002006810007900460000051009600078900800010080064009002050000"}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "000001002001030040050400106006070023070800600400000005023580000068007010700160300", resp["canonical"])
+}
+
+func TestErrors(t *testing.T) {
+	h := server.New(server.Options{MaxBodyBytes: 200})
+
+	code, resp := post(t, h, "/solve",
+		`{"grid": "CAT921003009000060000000500080403006007000800500700040003000000020000700800195000"}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	e := resp["error"].(map[string]interface{})
+	assert.Equal(t, "invalid_grid", e["code"])
+	assert.Equal(t, "Invalid rune 'C' in grid", e["message"])
+	assert.Equal(t, float64(0), e["index"])
+	assert.Equal(t, "C", e["rune"])
+
+	code, resp = post(t, h, "/rate", `{"grid": "0092100300"}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	e = resp["error"].(map[string]interface{})
+	assert.Equal(t, "Grid has invalid size '10'", e["message"])
+	assert.Equal(t, float64(10), e["size"])
+
+	code, resp = post(t, h, "/solve", `{"grid": "`+strings.Repeat("0", 300)+`"}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+	assert.Equal(t, "request_too_large", resp["error"].(map[string]interface{})["code"])
+
+	code, resp = post(t, h, "/solve", `{"grd": ""}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "invalid_json", resp["error"].(map[string]interface{})["code"])
+
+	code, resp = post(t, h, "/solve", `{"grid": "`+strings.Repeat("0", 81)+`", "strategies": ["guessing"]}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "Unknown strategy 'guessing'", resp["error"].(map[string]interface{})["message"])
+
+	req := httptest.NewRequest(http.MethodGet, "/solve", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestTimeout(t *testing.T) {
+	h := server.New(server.Options{Timeout: 1})
+
+	code, resp := post(t, h, "/solve",
+		`{"grid": "000921003009000060000000500080403006007000800500700040003000000020000700800195000"}`)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "timeout", resp["error"].(map[string]interface{})["code"])
+
+	// The brute force search of Validate stops too
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := server.Validate(ctx, server.GridRequest{
+		Grid: "000921003009000060000000500080403006007000800500700040003000000020000700800195000",
+	})
+	assert.Equal(t, context.Canceled, err)
+
+	_, err = server.Hint(ctx, server.GridRequest{
+		Grid: "000921003009000060000000500080403006007000800500700040003000000020000700800195000",
+	})
+	assert.Equal(t, context.Canceled, err)
+}
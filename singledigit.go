@@ -0,0 +1,216 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+// Kinds of houses
+const (
+	houseRow = iota
+	houseColumn
+	houseBox
+)
+
+// A conjugate pair is a house with only two candidates for a number,
+// one of them must be the number.
+type conjugatePair struct {
+	a, b  Cell
+	house int
+}
+
+// conjugatePairs returns the conjugate pairs of each number. A pair in a
+// box that is also in a row or a column is returned only once.
+func (s *Sudoku) conjugatePairs() [sudokuNumbers + 1][]conjugatePair {
+	var res [sudokuNumbers + 1][]conjugatePair
+
+	funs := []cellGetter{s.getCandidateRow, s.getCandidateColumn, s.getCandidateBox}
+
+	for house, fun := range funs {
+		for i := int8(1); i <= sudokuNumbers; i++ {
+			cells := fun(i)
+
+			for n := int8(1); n <= sudokuNumbers; n++ {
+				ncells := cells.Filter(func(c Cell) bool {
+					return c.Value == n
+				})
+
+				if len(ncells) != 2 {
+					continue
+				}
+
+				if house == houseBox && (ncells[0].Pos.eqRow(ncells[1].Pos) ||
+					ncells[0].Pos.eqColumn(ncells[1].Pos)) {
+					continue
+				}
+
+				res[n] = append(res[n], conjugatePair{a: ncells[0], b: ncells[1], house: house})
+			}
+		}
+	}
+
+	return res
+}
+
+// A turbot fish is two conjugate pairs a=b and c=d, where b sees c. Either
+// a or d is the number, so it can be eliminated from cells seeing both.
+type turbotMatcher func(p, q conjugatePair, a, b, c, d Cell) bool
+
+// Two parallel lines, the inner ends share the crossing line
+func isSkyscraper(p, q conjugatePair, a, b, c, d Cell) bool {
+	switch {
+	case p.house == houseRow && q.house == houseRow:
+		return b.Pos.eqColumn(c.Pos) && !a.Pos.eqColumn(d.Pos)
+	case p.house == houseColumn && q.house == houseColumn:
+		return b.Pos.eqRow(c.Pos) && !a.Pos.eqRow(d.Pos)
+	}
+
+	return false
+}
+
+// A row and a column, the inner ends share a box
+func isTwoStringKite(p, q conjugatePair, a, b, c, d Cell) bool {
+	if !((p.house == houseRow && q.house == houseColumn) ||
+		(p.house == houseColumn && q.house == houseRow)) {
+		return false
+	}
+
+	return b.Pos.eqBox(c.Pos) && !a.Pos.eqBox(b.Pos) && !d.Pos.eqBox(c.Pos)
+}
+
+func isTurbotFish(p, q conjugatePair, a, b, c, d Cell) bool {
+	return true
+}
+
+func (s *Sudoku) findTurbots(match turbotMatcher) finderResult {
+	res := deductions{}
+
+	for n, pairs := range s.conjugatePairs() {
+		for i, p := range pairs {
+			for _, q := range pairs[i+1:] {
+				for _, pe := range [][2]Cell{{p.a, p.b}, {p.b, p.a}} {
+					for _, qe := range [][2]Cell{{q.a, q.b}, {q.b, q.a}} {
+						a, b, c, d := pe[0], pe[1], qe[0], qe[1]
+
+						if a.Pos == c.Pos || a.Pos == d.Pos || b.Pos == c.Pos || b.Pos == d.Pos {
+							continue
+						}
+
+						if !b.Pos.sees(c.Pos) || !match(p, q, a, b, c, d) {
+							continue
+						}
+
+						nfound := s.Candidates.Filter(func(cell Cell) bool {
+							return cell.Value == int8(n) &&
+								cell.Pos != a.Pos && cell.Pos != b.Pos &&
+								cell.Pos != c.Pos && cell.Pos != d.Pos &&
+								cell.Pos.sees(a.Pos) && cell.Pos.sees(d.Pos)
+						})
+
+						res.add(finderResult{Solved: nil, Eliminated: nfound, Pattern: CellList{a, b, c, d}})
+					}
+				}
+			}
+		}
+	}
+
+	return res.result()
+}
+
+func (s *Sudoku) findSkyscrapers() finderResult {
+	return s.findTurbots(isSkyscraper)
+}
+
+func (s *Sudoku) findTwoStringKites() finderResult {
+	return s.findTurbots(isTwoStringKite)
+}
+
+func (s *Sudoku) findTurbotFish() finderResult {
+	return s.findTurbots(isTurbotFish)
+}
+
+// Empty rectangle: the candidates of a box lie in one row and one column
+// of it. With a conjugate pair in a line crossing the column outside the
+// box, the number is eliminated where the row meets the far end of the
+// pair, and the same with rows and columns swapped.
+func (s *Sudoku) findEmptyRectangles() finderResult {
+	d := deductions{}
+
+	pairs := s.conjugatePairs()
+
+	for box := int8(1); box <= sudokuNumbers; box++ {
+		bcells := s.getCandidateBox(box)
+
+		for n := int8(1); n <= sudokuNumbers; n++ {
+			cells := bcells.Filter(func(c Cell) bool {
+				return c.Value == n
+			})
+
+			if len(cells) < 2 {
+				continue
+			}
+
+			first := cells[0].Pos
+			row0 := ((first.Row-1)/sudokuBoxes)*sudokuBoxes + 1
+			col0 := ((first.Column-1)/sudokuBoxes)*sudokuBoxes + 1
+
+			for row := row0; row < row0+sudokuBoxes; row++ {
+				for col := col0; col < col0+sudokuBoxes; col++ {
+					if !cells.All(func(c Cell) bool {
+						return c.Pos.Row == row || c.Pos.Column == col
+					}) {
+						continue
+					}
+
+					// Otherwise all are in one line, that is pointing
+					if cells.All(func(c Cell) bool { return c.Pos.Row == row }) ||
+						cells.All(func(c Cell) bool { return c.Pos.Column == col }) {
+						continue
+					}
+
+					for _, pair := range pairs[n] {
+						for _, pe := range [][2]Cell{{pair.a, pair.b}, {pair.b, pair.a}} {
+							near, far := pe[0], pe[1]
+
+							if near.Pos.Box == box || far.Pos.Box == box {
+								continue
+							}
+
+							var target Pos
+							switch {
+							case pair.house == houseRow && near.Pos.Column == col &&
+								(far.Pos.Column-1)/sudokuBoxes != (col-1)/sudokuBoxes:
+								target = Pos{}.init(row, far.Pos.Column)
+							case pair.house == houseColumn && near.Pos.Row == row &&
+								(far.Pos.Row-1)/sudokuBoxes != (row-1)/sudokuBoxes:
+								target = Pos{}.init(far.Pos.Row, col)
+							default:
+								continue
+							}
+
+							nfound := s.Candidates.Filter(func(c Cell) bool {
+								return c.Value == n && c.Pos == target
+							})
+
+							d.add(finderResult{Solved: nil, Eliminated: nfound,
+								Pattern: append(copyCells(cells), near, far)})
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return d.result()
+}
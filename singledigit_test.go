@@ -0,0 +1,49 @@
+package sudoku_test
+
+import (
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+func TestSkyscraper(t *testing.T) {
+	// Rows 3 and 6, r3c2 and r6c1 see each other in box 1
+	grid := "000000020043100050702005900000070000900860002000203000809000006000000409000320007"
+
+	step := stalledHint(t, grid, "skyscraper")
+	assert.DeepEqual(t, sudoku.CellList{cell(4, 2, 4, 1), cell(5, 2, 4, 1)}, step.Eliminated)
+	assert.DeepEqual(t, sudoku.CellList{cell(3, 2, 1, 1), cell(3, 9, 3, 1), cell(6, 1, 4, 1), cell(6, 9, 6, 1)},
+		step.Pattern)
+}
+
+func TestTwoStringKite(t *testing.T) {
+	// Row 7 and column 9 joined in box 9
+	grid := "000000708700300000925840000004930005200050000308000020000106004400000300009000860"
+
+	step := stalledHint(t, grid, "2-string kite")
+	assert.DeepEqual(t, sudoku.CellList{cell(2, 5, 2, 9)}, step.Eliminated)
+	assert.DeepEqual(t, sudoku.CellList{cell(2, 9, 3, 9), cell(7, 5, 8, 9), cell(7, 8, 9, 9), cell(8, 9, 9, 9)},
+		step.Pattern)
+}
+
+func TestTurbotFish(t *testing.T) {
+	// Box 2, column 4 and row 6
+	grid := "003400008806079000070005000000300080000000400700094103000510230000006001090000050"
+
+	step := stalledHint(t, grid, "turbot fish")
+	assert.DeepEqual(t, sudoku.CellList{cell(1, 8, 3, 2)}, step.Eliminated)
+	assert.DeepEqual(t, sudoku.CellList{cell(1, 6, 2, 2), cell(2, 4, 2, 2), cell(6, 4, 5, 2), cell(6, 8, 6, 2)},
+		step.Pattern)
+}
+
+func TestEmptyRectangle(t *testing.T) {
+	// Box 5 in row 5 and column 4, conjugate pair in column 1
+	grid := "000002700030000600900000140210038097000000208400700000100806005500010800070004006"
+
+	step := stalledHint(t, grid, "empty rectangle")
+	assert.DeepEqual(t, sudoku.CellList{cell(1, 4, 2, 6)}, step.Eliminated)
+	assert.DeepEqual(t, sudoku.CellList{
+		cell(1, 1, 1, 6), cell(4, 4, 5, 6), cell(5, 1, 4, 6), cell(5, 4, 5, 6), cell(5, 5, 5, 6)},
+		step.Pattern)
+}
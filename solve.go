@@ -0,0 +1,284 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SolveStatus tells why solving stopped.
+type SolveStatus int
+
+const (
+	// StatusSolved means that the grid was solved completely.
+	StatusSolved SolveStatus = iota
+	// StatusStalled means that none of the strategies made progress.
+	StatusStalled
+	// StatusCancelled means that the context was cancelled or a
+	// time or step budget ran out.
+	StatusCancelled
+	// StatusContradiction means that the grid is invalid.
+	StatusContradiction
+)
+
+var statusNames = []string{"solved", "stalled", "cancelled", "contradiction"}
+
+func (st SolveStatus) String() string {
+	if st < 0 || int(st) >= len(statusNames) {
+		return fmt.Sprintf("SolveStatus(%d)", int(st))
+	}
+	return statusNames[st]
+}
+
+func (st SolveStatus) MarshalText() ([]byte, error) {
+	return []byte(st.String()), nil
+}
+
+func (st *SolveStatus) UnmarshalText(text []byte) error {
+	for i, name := range statusNames {
+		if name == string(text) {
+			*st = SolveStatus(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Invalid solve status '%s'", text)
+}
+
+// ErrStepLimit is reported when SolveOptions.MaxSteps was reached.
+var ErrStepLimit = errors.New("Step limit reached")
+
+// SolveOptions control SolveContext.
+type SolveOptions struct {
+	// Maximum number of progressing steps, zero means no limit
+	MaxSteps int
+	// Time to stop at, zero means no deadline
+	Deadline time.Time
+	// Names of the strategies to use in order, nil means the default
+	// strategies
+	Strategies []string
+	// Run all strategies at each step and apply only the simplest single
+	// deduction, one pattern, found: the lowest Sudoku Explainer
+	// difficulty, then the most candidates removed, then the first in
+	// AllStrategies order. Slower, but the path is the same whatever the
+	// order of the strategies.
+	Simplest bool
+}
+
+// SolveResult tells how far SolveContext got.
+type SolveResult struct {
+	Status SolveStatus
+	// Number of steps that made progress
+	Steps int
+	// Reason for cancellation or the contradiction found
+	Err error
+}
+
+// Strategies returns the names of the default strategies in solver order.
+func Strategies() []string {
+	return strategyNames(defaultStrategies())
+}
+
+// AllStrategies returns the names of all strategies, including the
+// optional ones, ordered by difficulty.
+func AllStrategies() []string {
+	return strategyNames(strategies)
+}
+
+func strategyNames(sts []strategy) []string {
+	names := make([]string, len(sts))
+
+	for i, st := range sts {
+		names[i] = st.name
+	}
+
+	return names
+}
+
+func selectStrategies(names []string) ([]strategy, error) {
+	if names == nil {
+		return defaultStrategies(), nil
+	}
+
+	res := make([]strategy, len(names))
+
+	for i, name := range names {
+		st, ok := findStrategy(name)
+		if !ok {
+			return nil, fmt.Errorf("Unknown strategy '%s'", name)
+		}
+		res[i] = st
+	}
+
+	return res, nil
+}
+
+// SolveContext solves the sudoku like Solve, but checks for cancellation
+// between strategies and honors the limits of opts. The progress made is
+// left in the sudoku. An error is returned only for invalid options.
+func (s *Sudoku) SolveContext(ctx context.Context, opts SolveOptions) (SolveResult, error) {
+	finders, err := selectStrategies(opts.Strategies)
+	if err != nil {
+		return SolveResult{}, err
+	}
+
+	if !opts.Deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, opts.Deadline)
+		defer cancel()
+	}
+
+	start := time.Now()
+	res := s.solve(ctx, finders, opts)
+
+	if s.stats != nil {
+		s.stats.recordPuzzle(res.Status == StatusSolved, time.Since(start))
+	}
+
+	return res, nil
+}
+
+func (s *Sudoku) solve(ctx context.Context, finders []strategy, opts SolveOptions) SolveResult {
+	res := SolveResult{}
+
+	if err := s.validate(); err != nil {
+		res.Status = StatusContradiction
+		res.Err = err
+		return res
+	}
+
+	// fmt.Println("begin", len(s.Candidates))
+	finderCount := len(finders)
+	finderIdx := 0
+
+PROGRESS:
+	for finderIdx < finderCount {
+		if len(s.Candidates) == 0 {
+			res.Status = StatusSolved
+			return res
+		}
+
+		if err := ctx.Err(); err != nil {
+			res.Status = StatusCancelled
+			res.Err = err
+			return res
+		}
+
+		if opts.MaxSteps > 0 && res.Steps >= opts.MaxSteps {
+			res.Status = StatusCancelled
+			res.Err = ErrStepLimit
+			return res
+		}
+
+		// fmt.Println("Finder", finderIdx)
+
+		var progress bool
+		if opts.Simplest {
+			progress = s.runSimplest(finders)
+			if !progress {
+				break
+			}
+		} else {
+			progress = s.runStrategy(finders[finderIdx])
+		}
+
+		if err := s.validate(); err != nil {
+			res.Status = StatusContradiction
+			res.Err = err
+			return res
+		}
+
+		if len(s.Candidates) == 0 {
+			res.Steps++
+			res.Status = StatusSolved
+			return res
+		}
+
+		if progress {
+			// fmt.Println("progress", len(s.Candidates))
+			res.Steps++
+			finderIdx = 0
+			continue PROGRESS
+		}
+		finderIdx++
+	}
+
+	res.Status = StatusStalled
+	return res
+}
+
+// Hint returns the step Solve would take next without applying it.
+func (s *Sudoku) Hint() (Step, bool) {
+	step, ok, _ := s.HintWith(nil)
+
+	return step, ok
+}
+
+// HintWith is like Hint, but tries only the named strategies, in order.
+func (s *Sudoku) HintWith(names []string) (Step, bool, error) {
+	finders, err := selectStrategies(names)
+	if err != nil {
+		return Step{}, false, err
+	}
+
+	if len(s.Candidates) == 0 {
+		return Step{}, false, nil
+	}
+
+	for _, st := range finders {
+		res := st.fun(s)
+		if len(res.Solved) == 0 && len(res.Eliminated) == 0 {
+			continue
+		}
+
+		c := s.clone()
+		c.apply(st.name, res)
+
+		return newStep(1, st.name, res, s.Candidates, c.Candidates), true, nil
+	}
+
+	return Step{}, false, nil
+}
+
+// ApplyStep applies the placements and eliminations of a step, for
+// example one returned by Hint. Eliminations of candidates that are
+// already gone are ignored, placements must be candidates. A step that
+// would leave the grid invalid is not applied.
+func (s *Sudoku) ApplyStep(step Step) error {
+	masks := candidateMasks(s.Candidates)
+
+	for _, c := range step.Solved {
+		if masks[c.Pos.index()]&(1<<uint(c.Value)) == 0 {
+			return fmt.Errorf("%d is not a candidate of cell (%d, %d)", c.Value, c.Pos.Row, c.Pos.Column)
+		}
+	}
+
+	res := finderResult{Solved: step.Solved, Eliminated: step.Eliminated}
+
+	c := s.clone()
+	c.apply(step.Strategy, res)
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	s.apply(step.Strategy, res)
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package sudoku_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+func TestSolveContextStatus(t *testing.T) {
+	tests := []struct {
+		grid   string
+		status sudoku.SolveStatus
+	}{
+		{"000040700500780020070002006810007900460000051009600078900800010080064009002050000", sudoku.StatusSolved},
+		{"000704005020010070000080002090006250600070008053200010400090000030060090200407000", sudoku.StatusStalled},
+		{"110040700500780020070002006810007900460000051009600078900800010080064009002050000", sudoku.StatusContradiction},
+	}
+
+	for _, test := range tests {
+		s, err := sudoku.NewSudoku(test.grid)
+		assert.NilError(t, err)
+
+		res, err := s.SolveContext(context.Background(), sudoku.SolveOptions{})
+		assert.NilError(t, err)
+		assert.Equal(t, test.status, res.Status, test.grid)
+	}
+}
+
+func TestSolveContextLimits(t *testing.T) {
+	grid := "000040700500780020070002006810007900460000051009600078900800010080064009002050000"
+
+	s, err := sudoku.NewSudoku(grid)
+	assert.NilError(t, err)
+
+	res, err := s.SolveContext(context.Background(), sudoku.SolveOptions{MaxSteps: 3})
+	assert.NilError(t, err)
+	assert.Equal(t, sudoku.StatusCancelled, res.Status)
+	assert.Equal(t, sudoku.ErrStepLimit, res.Err)
+	assert.Equal(t, 3, res.Steps)
+	assert.Assert(t, s.GetGridString() != grid)
+
+	// Continue from the partial progress
+	res, err = s.SolveContext(context.Background(), sudoku.SolveOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, sudoku.StatusSolved, res.Status)
+
+	s, err = sudoku.NewSudoku(grid)
+	assert.NilError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res, err = s.SolveContext(ctx, sudoku.SolveOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, sudoku.StatusCancelled, res.Status)
+	assert.Equal(t, context.Canceled, res.Err)
+	assert.Equal(t, grid, s.GetGridString())
+
+	res, err = s.SolveContext(context.Background(), sudoku.SolveOptions{Deadline: time.Now().Add(-time.Second)})
+	assert.NilError(t, err)
+	assert.Equal(t, sudoku.StatusCancelled, res.Status)
+	assert.Equal(t, context.DeadlineExceeded, res.Err)
+}
+
+func TestSolveContextStrategies(t *testing.T) {
+	grid := "000921003009000060000000500080403006007000800500700040003000000020000700800195000"
+
+	s, err := sudoku.NewSudoku(grid)
+	assert.NilError(t, err)
+
+	res, err := s.SolveContext(context.Background(), sudoku.SolveOptions{
+		Strategies: []string{"singles (simple)", "singles"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, sudoku.StatusStalled, res.Status)
+
+	_, err = s.SolveContext(context.Background(), sudoku.SolveOptions{
+		Strategies: []string{"guessing"},
+	})
+	assert.Error(t, err, "Unknown strategy 'guessing'")
+
+	assert.Equal(t, "singles (simple)", sudoku.Strategies()[0])
+}
+
+func TestSolveContextSimplest(t *testing.T) {
+	grid := "000704005020010070000080002090006250600070008053200010400090000030060090200407000"
+
+	solve := func(names []string) *sudoku.Trace {
+		s, err := sudoku.NewSudoku(grid)
+		assert.NilError(t, err)
+
+		trace := &sudoku.Trace{}
+		s.SetTrace(trace)
+
+		res, err := s.SolveContext(context.Background(), sudoku.SolveOptions{Strategies: names, Simplest: true})
+		assert.NilError(t, err)
+		assert.Equal(t, sudoku.StatusStalled, res.Status)
+		assert.Equal(t, len(trace.Steps), res.Steps)
+
+		return trace
+	}
+
+	trace := solve(nil)
+
+	// Hidden singles are simpler than naked ones
+	assert.Equal(t, "singles", trace.Steps[0].Strategy)
+
+	// Each step is a single deduction
+	sizes := map[string]int{
+		"naked pairs": 2, "hidden pairs": 2, "naked triples": 3,
+		"hidden triples": 3, "naked quads": 4, "hidden quads": 4,
+		"pointing pairs": 0, "box/line reduction": 0,
+	}
+
+	for _, step := range trace.Steps {
+		assert.Assert(t, len(step.Solved) <= 1, "%d %s", step.Number, step.Strategy)
+
+		poss := map[sudoku.Pos]bool{}
+		values := map[int8]bool{}
+		for _, c := range step.Pattern {
+			poss[c.Pos] = true
+			values[c.Value] = true
+		}
+
+		if size, ok := sizes[step.Strategy]; ok {
+			if size > 0 {
+				assert.Equal(t, size, len(poss), "%d %s", step.Number, step.Strategy)
+			} else {
+				assert.Equal(t, 1, len(values), "%d %s", step.Number, step.Strategy)
+			}
+		}
+	}
+
+	// No strategy had a simpler step available
+	s, err := sudoku.NewSudoku(grid)
+	assert.NilError(t, err)
+
+	for _, step := range trace.Steps {
+		for _, name := range sudoku.Strategies() {
+			hint, ok, err := s.HintWith([]string{name})
+			assert.NilError(t, err)
+			assert.Assert(t, !ok || hint.SEDifficulty() >= step.SEDifficulty(), "%d %s", step.Number, name)
+		}
+
+		assert.NilError(t, s.ApplyStep(step))
+	}
+
+	// The path does not depend on the order of the strategies
+	names := sudoku.Strategies()
+	reversed := make([]string, len(names))
+	for i, name := range names {
+		reversed[len(names)-1-i] = name
+	}
+
+	assert.DeepEqual(t, trace, solve(reversed))
+}
+
+func TestApplyStepInvalid(t *testing.T) {
+	grid := "000040700500780020070002006810007900460000051009600078900800010080064009002050000"
+
+	s, err := sudoku.NewSudoku(grid)
+	assert.NilError(t, err)
+
+	trace := &sudoku.Trace{}
+	s.SetTrace(trace)
+
+	// r1c1 has 1, 2, 3 and 6 left
+	before := s.Candidates
+	err = s.ApplyStep(sudoku.Step{Strategy: "test", Eliminated: sudoku.CellList{
+		cell(1, 1, 1, 1), cell(1, 1, 1, 2), cell(1, 1, 1, 3), cell(1, 1, 1, 6),
+	}})
+	assert.Error(t, err, "No candidates left for cell (1, 1)")
+	assert.Equal(t, grid, s.GetGridString())
+	assert.DeepEqual(t, before, s.Candidates)
+	assert.Equal(t, 0, len(trace.Steps))
+
+	// 1 is a candidate of both r1c1 and r1c3, but not of both at once
+	err = s.ApplyStep(sudoku.Step{Strategy: "test", Solved: sudoku.CellList{
+		cell(1, 1, 1, 1), cell(1, 3, 1, 1),
+	}})
+	assert.ErrorContains(t, err, "Invalid row 1")
+	assert.Equal(t, grid, s.GetGridString())
+	assert.DeepEqual(t, before, s.Candidates)
+	assert.Equal(t, 0, len(trace.Steps))
+
+	err = s.ApplyStep(sudoku.Step{Strategy: "test", Solved: sudoku.CellList{cell(1, 1, 1, 9)}})
+	assert.Error(t, err, "9 is not a candidate of cell (1, 1)")
+}
+
+// Steps tried on copies of the grid, like hints, are not logged
+func TestHintNoLogging(t *testing.T) {
+	s, err := sudoku.NewSudoku("000921003009000060000000500080403006007000800500700040003000000020000700800195000")
+	assert.NilError(t, err)
+	s.EnableLogging(true)
+
+	out := captureStdout(t, func() {
+		_, ok, err := s.HintWith([]string{"singles (simple)"})
+		assert.NilError(t, err)
+		assert.Assert(t, ok)
+	})
+	assert.Equal(t, "", out)
+}
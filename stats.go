@@ -0,0 +1,220 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// StrategyStats holds the counters of a single strategy.
+type StrategyStats struct {
+	Strategy    string        `json:"strategy"`
+	Invocations int           `json:"invocations"`
+	Hits        int           `json:"hits"`
+	Placed      int           `json:"placed"`
+	Eliminated  int           `json:"eliminated"`
+	Time        time.Duration `json:"time_ns"`
+}
+
+// Stats collects solver statistics. The same collector can be attached
+// to any number of sudokus, also concurrently, to aggregate over a batch.
+type Stats struct {
+	mu sync.Mutex
+
+	puzzles    int
+	solved     int
+	time       time.Duration
+	strategies map[string]*StrategyStats
+}
+
+func NewStats() *Stats {
+	return &Stats{strategies: make(map[string]*StrategyStats)}
+}
+
+// SetStats attaches a statistics collector to the sudoku, nil detaches
+// the current one.
+func (s *Sudoku) SetStats(st *Stats) {
+	s.stats = st
+}
+
+func (st *Stats) get(name string) *StrategyStats {
+	if st.strategies == nil {
+		st.strategies = make(map[string]*StrategyStats)
+	}
+
+	ss, ok := st.strategies[name]
+	if !ok {
+		ss = &StrategyStats{Strategy: name}
+		st.strategies[name] = ss
+	}
+
+	return ss
+}
+
+func (st *Stats) record(name string, res finderResult, progress bool, elapsed time.Duration) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	ss := st.get(name)
+	ss.Invocations++
+	ss.Time += elapsed
+
+	if progress {
+		ss.Hits++
+		ss.Placed += len(normalizeCells(res.Solved))
+		ss.Eliminated += len(normalizeCells(res.Eliminated))
+	}
+}
+
+func (st *Stats) recordPuzzle(solved bool, elapsed time.Duration) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.puzzles++
+	if solved {
+		st.solved++
+	}
+	st.time += elapsed
+}
+
+// Merge adds the counters of other to st.
+func (st *Stats) Merge(other *Stats) {
+	if st == other {
+		return
+	}
+
+	// Copy other first so that the two locks are never held together,
+	// merging both ways concurrently would deadlock otherwise
+	other.mu.Lock()
+	puzzles, solved, elapsed := other.puzzles, other.solved, other.time
+	strategies := make([]StrategyStats, 0, len(other.strategies))
+	for _, oss := range other.strategies {
+		strategies = append(strategies, *oss)
+	}
+	other.mu.Unlock()
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.puzzles += puzzles
+	st.solved += solved
+	st.time += elapsed
+
+	for _, oss := range strategies {
+		ss := st.get(oss.Strategy)
+		ss.Invocations += oss.Invocations
+		ss.Hits += oss.Hits
+		ss.Placed += oss.Placed
+		ss.Eliminated += oss.Eliminated
+		ss.Time += oss.Time
+	}
+}
+
+// Puzzles returns the number of puzzles solved with the collector
+// attached and how many of them were solved completely.
+func (st *Stats) Puzzles() (total, solved int) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	return st.puzzles, st.solved
+}
+
+func strategyIndex(name string) int {
+	for i, s := range strategies {
+		if s.name == name {
+			return i
+		}
+	}
+
+	return len(strategies)
+}
+
+// Strategies returns the counters of every strategy seen, in solver order.
+func (st *Stats) Strategies() []StrategyStats {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	res := make([]StrategyStats, 0, len(st.strategies))
+	for _, ss := range st.strategies {
+		res = append(res, *ss)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		ii, ij := strategyIndex(res[i].Strategy), strategyIndex(res[j].Strategy)
+		if ii != ij {
+			return ii < ij
+		}
+		return res[i].Strategy < res[j].Strategy
+	})
+
+	return res
+}
+
+type statsJSON struct {
+	Puzzles    int             `json:"puzzles"`
+	Solved     int             `json:"solved"`
+	Time       time.Duration   `json:"time_ns"`
+	Strategies []StrategyStats `json:"strategies"`
+}
+
+func (st *Stats) MarshalJSON() ([]byte, error) {
+	strategies := st.Strategies()
+
+	st.mu.Lock()
+	sj := statsJSON{Puzzles: st.puzzles, Solved: st.solved, Time: st.time, Strategies: strategies}
+	st.mu.Unlock()
+
+	return json.Marshal(sj)
+}
+
+// WriteTable writes the statistics as a human readable table.
+func (st *Stats) WriteTable(w io.Writer) error {
+	strategies := st.Strategies()
+	puzzles, solved := st.Puzzles()
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "strategy\tcalls\thits\tplaced\teliminated\ttime\n")
+
+	var total StrategyStats
+	for _, ss := range strategies {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%v\n",
+			ss.Strategy, ss.Invocations, ss.Hits, ss.Placed, ss.Eliminated, ss.Time)
+
+		total.Invocations += ss.Invocations
+		total.Hits += ss.Hits
+		total.Placed += ss.Placed
+		total.Eliminated += ss.Eliminated
+		total.Time += ss.Time
+	}
+
+	fmt.Fprintf(tw, "total\t%d\t%d\t%d\t%d\t%v\n",
+		total.Invocations, total.Hits, total.Placed, total.Eliminated, total.Time)
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "%d puzzles, %d solved\n", puzzles, solved)
+	return err
+}
@@ -0,0 +1,107 @@
+package sudoku_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+func TestStats(t *testing.T) {
+	grids := []string{
+		"000040700500780020070002006810007900460000051009600078900800010080064009002050000",
+		"700600008800030000090000310006740005005806900400092100087000020000060009600008001",
+		"000704005020010070000080002090006250600070008053200010400090000030060090200407000",
+	}
+
+	stats := sudoku.NewStats()
+
+	for _, grid := range grids {
+		s, err := sudoku.NewSudoku(grid)
+		assert.NilError(t, err)
+
+		s.SetStats(stats)
+		s.Solve()
+	}
+
+	total, solved := stats.Puzzles()
+	assert.Equal(t, 3, total)
+	assert.Equal(t, 2, solved)
+
+	strategies := stats.Strategies()
+	assert.Assert(t, len(strategies) > 0)
+	assert.Equal(t, "singles (simple)", strategies[0].Strategy)
+
+	placed := 0
+	for _, ss := range strategies {
+		assert.Assert(t, ss.Hits <= ss.Invocations)
+		placed += ss.Placed
+	}
+	assert.Assert(t, placed > 0)
+
+	var buf bytes.Buffer
+	assert.NilError(t, stats.WriteTable(&buf))
+	assert.Assert(t, strings.Contains(buf.String(), "3 puzzles, 2 solved"))
+
+	data, err := json.Marshal(stats)
+	assert.NilError(t, err)
+
+	var decoded struct {
+		Puzzles    int                    `json:"puzzles"`
+		Strategies []sudoku.StrategyStats `json:"strategies"`
+	}
+	assert.NilError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, 3, decoded.Puzzles)
+	assert.DeepEqual(t, strategies, decoded.Strategies)
+
+	merged := sudoku.NewStats()
+	merged.Merge(stats)
+	merged.Merge(stats)
+	total, solved = merged.Puzzles()
+	assert.Equal(t, 6, total)
+	assert.Equal(t, 4, solved)
+}
+
+func TestStatsMergeConcurrent(t *testing.T) {
+	s1, s2 := sudoku.NewStats(), sudoku.NewStats()
+	for _, stats := range []*sudoku.Stats{s1, s2} {
+		s, err := sudoku.NewSudoku("000040700500780020070002006810007900460000051009600078900800010080064009002050000")
+		assert.NilError(t, err)
+
+		s.SetStats(stats)
+		s.Solve()
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 1000; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			s1.Merge(s2)
+		}()
+		go func() {
+			defer wg.Done()
+			s2.Merge(s1)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestStatsZeroValue(t *testing.T) {
+	var stats sudoku.Stats
+
+	s, err := sudoku.NewSudoku("000040700500780020070002006810007900460000051009600078900800010080064009002050000")
+	assert.NilError(t, err)
+
+	s.SetStats(&stats)
+	assert.Assert(t, s.Solve())
+
+	total, solved := stats.Puzzles()
+	assert.Equal(t, 1, total)
+	assert.Equal(t, 1, solved)
+	assert.Assert(t, len(stats.Strategies()) > 0)
+}
@@ -18,9 +18,11 @@
 package sudoku
 
 import (
+	"context"
 	"fmt"
 	"github.com/deckarep/golang-set"
-	"unicode"
+	"os"
+	"time"
 )
 
 const (
@@ -33,8 +35,12 @@ type Sudoku struct {
 	Solved     CellList
 	Candidates CellList
 
+	givens [sudokuGridSize]bool
+
 	enableLogging bool
 	logger        Logger
+	trace         *Trace
+	stats         *Stats
 }
 
 func (s Sudoku) logEliminated(strategy string, cells ...Cell) {
@@ -52,6 +58,57 @@ func (s Sudoku) logSolved(strategy string, cells ...Cell) {
 type finderResult struct {
 	Solved     CellList
 	Eliminated CellList
+	// Candidates forming the pattern the deduction is based on
+	Pattern CellList
+	// Color classes of a coloring pattern
+	Colors []CellList
+	// Branches of a forcing chain
+	Chain []ForcingBranch
+	// The single deductions the result is made of, one pattern each, nil
+	// if there is only one
+	parts []finderResult
+}
+
+// deductions collects the results of a finder one pattern at a time.
+type deductions struct {
+	parts []finderResult
+}
+
+// add adds a deduction, or the parts of a combined one. Results without
+// progress are skipped.
+func (d *deductions) add(res finderResult) {
+	if res.parts != nil {
+		d.parts = append(d.parts, res.parts...)
+	} else if len(res.Solved) > 0 || len(res.Eliminated) > 0 {
+		d.parts = append(d.parts, res)
+	}
+}
+
+// result combines the deductions into one result.
+func (d *deductions) result() finderResult {
+	found := CellList{}
+	eliminated := CellList{}
+	pattern := CellList{}
+
+	for _, part := range d.parts {
+		found = append(found, part.Solved...)
+		eliminated = append(eliminated, part.Eliminated...)
+		pattern = append(pattern, part.Pattern...)
+	}
+
+	res := finderResult{
+		Solved:     uniqueCells(found),
+		Eliminated: uniqueCells(eliminated),
+		Pattern:    uniqueCells(pattern),
+	}
+
+	if len(d.parts) > 1 {
+		res.parts = d.parts
+	} else if len(d.parts) == 1 {
+		res = d.parts[0]
+	}
+
+	return res
 }
 
 func (s Sudoku) getCell(row, col int8) Cell {
@@ -105,7 +162,7 @@ func (s Sudoku) getCellNumbers(pos Pos) cellNumbers {
 	return getCellNumbers(pos, s.Candidates)
 }
 
-func (s *Sudoku) validateSolved() {
+func (s *Sudoku) validateSolved() error {
 	type pair struct {
 		desc string
 		fun  func(int8) CellList
@@ -120,14 +177,33 @@ func (s *Sudoku) validateSolved() {
 		for _, p := range pairs {
 			set := p.fun(i)
 			if !validateSet(set) {
-				panic(fmt.Sprintf("Invalid %s %d %v", p.desc, i, set))
+				return fmt.Errorf("Invalid %s %d %v", p.desc, i, set)
 			}
 		}
 	}
+
+	return nil
+}
+
+// Every unsolved cell must have at least one candidate left
+func (s *Sudoku) validateCandidates() error {
+	masks := candidateMasks(s.Candidates)
+
+	for i, cell := range s.Solved {
+		if cell.Value == 0 && masks[i] == 0 {
+			return fmt.Errorf("No candidates left for cell (%d, %d)", cell.Pos.Row, cell.Pos.Column)
+		}
+	}
+
+	return nil
 }
 
-func (s *Sudoku) validate() {
-	s.validateSolved()
+func (s *Sudoku) validate() error {
+	if err := s.validateSolved(); err != nil {
+		return err
+	}
+
+	return s.validateCandidates()
 }
 
 func NewSudoku(grid string) (*Sudoku, error) {
@@ -143,13 +219,57 @@ func NewSudoku(grid string) (*Sudoku, error) {
 	return &s, nil
 }
 
+// Validate checks that no number is repeated in a row, column or box
+// and that every unsolved cell has candidates left.
+func (s *Sudoku) Validate() error {
+	return s.validate()
+}
+
+// Given tells if the cell was given in the grid the sudoku was created
+// from, as opposed to solved.
+func (s *Sudoku) Given(row, col int8) bool {
+	if row < 1 || row > sudokuNumbers || col < 1 || col > sudokuNumbers {
+		return false
+	}
+
+	return s.givens[int(row-1)*sudokuNumbers+int(col-1)]
+}
+
+func (s *Sudoku) clone() *Sudoku {
+	c := *s
+	c.Solved = copyCells(s.Solved)
+	c.Candidates = copyCells(s.Candidates)
+	c.trace = nil
+	c.stats = nil
+	c.enableLogging = false
+
+	return &c
+}
+
 func (s *Sudoku) EnableLogging(state bool) {
 	s.enableLogging = state
 }
 
+// GridError is returned when a grid string cannot be parsed.
+type GridError struct {
+	// Size of the grid string
+	Size int
+	// Byte offset and value of an invalid rune, Index is -1 for a
+	// grid of invalid size
+	Index int
+	Rune  rune
+}
+
+func (e *GridError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("Grid has invalid size '%d'", e.Size)
+	}
+	return fmt.Sprintf("Invalid rune '%c' in grid", e.Rune)
+}
+
 func (s *Sudoku) initGrid(grids string) error {
 	if len(grids) != sudokuGridSize {
-		return fmt.Errorf("Grid has invalid size '%d'", len(grids))
+		return &GridError{Size: len(grids), Index: -1}
 	}
 
 	var row int8 = 1
@@ -162,14 +282,15 @@ func (s *Sudoku) initGrid(grids string) error {
 	zero := rune('0')
 
 	for i, c = range grids {
-		if !unicode.IsDigit(c) {
-			return fmt.Errorf("Invalid rune '%c' in grid", c)
+		if c < '0' || c > '9' {
+			return &GridError{Size: len(grids), Index: i, Rune: c}
 		}
 
 		ascii := int8(c - zero)
 
 		idx := (row - 1) * sudokuNumbers + (column - 1)
 		s.Solved[idx] = Cell{}.init(row, column, ascii)
+		s.givens[idx] = ascii != 0
 
 		if (i + 1) % sudokuNumbers == 0 {
 			row++
@@ -206,26 +327,7 @@ func (s *Sudoku) initCandidates() {
 }
 
 func (s Sudoku) PrintGrid() {
-	fmt.Print("+-------------------+\n")
-	for i, cell := range s.Solved {
-		if (i + 1) % sudokuNumbers == 1 {
-			fmt.Print("| ")
-		}
-
-		v := cell.Value
-		if v == 0 {
-			fmt.Printf(".")
-		} else {
-			fmt.Printf("%d", v)
-		}
-
-		if (i + 1) % sudokuNumbers == 0 {
-			fmt.Print(" |\n")
-		} else {
-			fmt.Print(" ")
-		}
-	}
-	fmt.Print("+-------------------+\n")
+	s.WriteGrid(os.Stdout, GridStyle{Layout: LayoutFramed})
 }
 
 func (s Sudoku) GetGridString() string {
@@ -279,7 +381,7 @@ func (s *Sudoku) updateCandidates(eliminated CellList) {
 // Simple case where there is only one candidate left for a cell
 func (s *Sudoku) findSinglesSimple() finderResult {
 	poss := s.ucpos()
-	found := CellList{}
+	d := deductions{}
 
 	for _, pos := range poss {
 		cands := s.Candidates.Filter(func(c Cell) bool {
@@ -287,19 +389,17 @@ func (s *Sudoku) findSinglesSimple() finderResult {
 		})
 
 		if len(cands) == 1 {
-			found = append(found, cands[0])
+			d.add(finderResult{Solved: cands, Eliminated: nil, Pattern: cands})
 		}
 	}
 
-	found = uniqueCells(found)
-	return finderResult{Solved: found, Eliminated: nil}
+	return d.result()
 }
 
 func (s *Sudoku) finder(cf cellFinder) finderResult {
 	funs := []cellGetter{s.getCandidateRow, s.getCandidateColumn, s.getCandidateBox}
 
-	found := CellList{}
-	eliminated := CellList{}
+	d := deductions{}
 
 	for _, fun := range funs {
 		for i := 1; i <= sudokuNumbers; i++ {
@@ -309,22 +409,11 @@ func (s *Sudoku) finder(cf cellFinder) finderResult {
 				continue
 			}
 
-			fresult := cf(cells)
-
-			if len(fresult.Solved) > 0 {
-				found = append(found, fresult.Solved...)
-			}
-
-			if len(fresult.Eliminated) > 0 {
-				eliminated = append(eliminated, fresult.Eliminated...)
-			}
+			d.add(cf(cells))
 		}
 	}
 
-	found = uniqueCells(found)
-	eliminated = uniqueCells(eliminated)
-
-	return finderResult{Solved: found, Eliminated: eliminated}
+	return d.result()
 }
 
 // Only one candidate left for a number in row / column / box
@@ -333,7 +422,7 @@ func (s *Sudoku) findSingles() finderResult {
 		nums := uniqueNumbers(cells)
 		// fmt.Println(nums)
 
-		found := CellList{}
+		d := deductions{}
 
 		for _, n := range nums {
 			ncells := cells.Filter(func(cell Cell) bool {
@@ -341,12 +430,11 @@ func (s *Sudoku) findSingles() finderResult {
 			})
 
 			if len(ncells) == 1 {
-				found = append(found, ncells[0])
+				d.add(finderResult{Solved: ncells, Eliminated: nil, Pattern: ncells})
 			}
 		}
 
-		found = uniqueCells(found)
-		return finderResult{Solved: found, Eliminated: nil}
+		return d.result()
 	})
 }
 
@@ -365,7 +453,7 @@ func findNakedGroupsInSet(limit int, cands CellList) finderResult {
 	unums := ncounts.MapInt8(func(nc numCount) int8 { return nc.num })
 
 	// fmt.Println("counts", cands, ncounts, unums)
-	found := CellList{}
+	d := deductions{}
 
 	combs := newCombination(len(unums), limit)
 	for {
@@ -408,40 +496,31 @@ func findNakedGroupsInSet(limit int, cands CellList) finderResult {
 			nfound := cands.Filter(func(c Cell) bool {
 				return !matchedPositions.Contains(c.Pos) && set1.Contains(c.Value)
 			})
-			found = append(found, nfound...)
+
+			d.add(finderResult{Solved: nil, Eliminated: nfound, Pattern: cands.Filter(func(c Cell) bool {
+				return matchedPositions.Contains(c.Pos)
+			})})
 		}
 	}
 
-	return finderResult{Solved: nil, Eliminated: found}
+	return d.result()
 }
 
 func (s *Sudoku) findNakedPairs() finderResult {
 	return s.finder(func(cells CellList) finderResult {
-		found := findNakedGroupsInSet(2, cells)
-
-		found.Eliminated = uniqueCells(found.Eliminated)
-
-		return found
+		return findNakedGroupsInSet(2, cells)
 	})
 }
 
 func (s *Sudoku) findNakedTriples() finderResult {
 	return s.finder(func(cells CellList) finderResult {
-		found := findNakedGroupsInSet(3, cells)
-
-		found.Eliminated = uniqueCells(found.Eliminated)
-
-		return found
+		return findNakedGroupsInSet(3, cells)
 	})
 }
 
 func (s *Sudoku) findNakedQuads() finderResult {
 	return s.finder(func(cells CellList) finderResult {
-		found := findNakedGroupsInSet(4, cells)
-
-		found.Eliminated = uniqueCells(found.Eliminated)
-
-		return found
+		return findNakedGroupsInSet(4, cells)
 	})
 }
 
@@ -460,7 +539,7 @@ func findHiddenGroupsInSet(limit int, cands CellList) finderResult {
 	unums := ncounts.MapInt8(func(nc numCount) int8 { return nc.num })
 
 	// fmt.Println("counts", cands, ncounts, unums)
-	found := CellList{}
+	d := deductions{}
 
 	combs := newCombination(len(unums), limit)
 	for {
@@ -496,45 +575,36 @@ func findHiddenGroupsInSet(limit int, cands CellList) finderResult {
 				// true if position matches but number is not in the combination
 				return matchedPositions.Contains(c.Pos) && !set1.Contains(c.Value)
 			})
-			found = append(found, nfound...)
+
+			d.add(finderResult{Solved: nil, Eliminated: nfound, Pattern: cands.Filter(func(c Cell) bool {
+				return matchedPositions.Contains(c.Pos) && set1.Contains(c.Value)
+			})})
 		}
 	}
 
-	return finderResult{Solved: nil, Eliminated: found}
+	return d.result()
 }
 
 func (s *Sudoku) findHiddenPairs() finderResult {
 	return s.finder(func(cells CellList) finderResult {
-		found := findHiddenGroupsInSet(2, cells)
-
-		found.Eliminated = uniqueCells(found.Eliminated)
-
-		return found
+		return findHiddenGroupsInSet(2, cells)
 	})
 }
 
 func (s *Sudoku) findHiddenTriples() finderResult {
 	return s.finder(func(cells CellList) finderResult {
-		found := findHiddenGroupsInSet(3, cells)
-
-		found.Eliminated = uniqueCells(found.Eliminated)
-
-		return found
+		return findHiddenGroupsInSet(3, cells)
 	})
 }
 
 func (s *Sudoku) findHiddenQuads() finderResult {
 	return s.finder(func(cells CellList) finderResult {
-		found := findHiddenGroupsInSet(4, cells)
-
-		found.Eliminated = uniqueCells(found.Eliminated)
-
-		return found
+		return findHiddenGroupsInSet(4, cells)
 	})
 }
 
 func (s *Sudoku) findPointingPairs() finderResult {
-	found := CellList{}
+	d := deductions{}
 
 	var boxnum int8
 	for boxnum = 1; boxnum <= sudokuNumbers; boxnum++ {
@@ -563,18 +633,16 @@ func (s *Sudoku) findPointingPairs() finderResult {
 				return c.Value == n && !cells[0].Pos.eqBox(c.Pos)
 			})
 
-			if len(nfound) > 0 {
-				// fmt.Println("pointing pairs", boxnum, n, cells, nfound)
-				found = append(found, nfound...)
-			}
+			// fmt.Println("pointing pairs", boxnum, n, cells, nfound)
+			d.add(finderResult{Solved: nil, Eliminated: nfound, Pattern: cells})
 		}
 	}
 
-	return finderResult{Solved: nil, Eliminated: found}
+	return d.result()
 }
 
 func (s *Sudoku) findBoxlineReduction() finderResult {
-	found := CellList{}
+	d := deductions{}
 
 	type pair struct {
 		getCells   func(int8) CellList
@@ -624,17 +692,22 @@ func (s *Sudoku) findBoxlineReduction() finderResult {
 				})
 
 				// fmt.Println("boxline found", nfound)
-				found = append(found, nfound...)
+				d.add(finderResult{Solved: nil, Eliminated: nfound, Pattern: ncells})
 			}
 		}
 	}
 
-	return finderResult{Solved: nil, Eliminated: found}
+	return d.result()
 }
 
-func (s *Sudoku) findYWings() finderResult {
-	found := CellList{}
-	interesting := make(map[Pos][]int8)
+// cellCandidates returns the candidates of the unsolved cells that have
+// from min to max candidates.
+func (s *Sudoku) cellCandidates(min, max int) map[Pos][]int8 {
+	res := make(map[Pos][]int8)
+
+	if len(s.Candidates) == 0 {
+		return res
+	}
 
 	prev := s.Candidates[0]
 	nums := []int8{prev.Value}
@@ -643,8 +716,8 @@ func (s *Sudoku) findYWings() finderResult {
 		if prev.Pos == cell.Pos {
 			nums = append(nums, cell.Value)
 		} else {
-			if len(nums) == 2 {
-				interesting[prev.Pos] = nums
+			if len(nums) >= min && len(nums) <= max {
+				res[prev.Pos] = nums
 			}
 
 			nums = []int8{cell.Value}
@@ -652,10 +725,17 @@ func (s *Sudoku) findYWings() finderResult {
 		prev = cell
 	}
 
-	if len(nums) == 2 {
-		interesting[prev.Pos] = nums
+	if len(nums) >= min && len(nums) <= max {
+		res[prev.Pos] = nums
 	}
 
+	return res
+}
+
+func (s *Sudoku) findYWings() finderResult {
+	d := deductions{}
+	interesting := s.cellCandidates(2, 2)
+
 	poss := []Pos{}
 	for key := range interesting {
 		poss = append(poss, key)
@@ -748,36 +828,18 @@ func (s *Sudoku) findYWings() finderResult {
 				return c.Value == n && c.Pos.sees(w1) && c.Pos.sees(w2)
 			})
 
-			found = append(found, nfound...)
+			d.add(finderResult{Solved: nil, Eliminated: nfound, Pattern: s.Candidates.Filter(func(c Cell) bool {
+				return c.Pos == w1 || c.Pos == pivot || c.Pos == w2
+			})})
 		}
 	}
 
-	return finderResult{Solved: nil, Eliminated: found}
+	return d.result()
 }
 
 func (s *Sudoku) findXYZWings() finderResult {
-	found := CellList{}
-	interesting := make(map[Pos][]int8)
-
-	prev := s.Candidates[0]
-	nums := []int8{prev.Value}
-
-	for _, cell := range s.Candidates[1:] {
-		if prev.Pos == cell.Pos {
-			nums = append(nums, cell.Value)
-		} else {
-			if len(nums) == 2 || len(nums) == 3 {
-				interesting[prev.Pos] = nums
-			}
-
-			nums = []int8{cell.Value}
-		}
-		prev = cell
-	}
-
-	if len(nums) == 2 || len(nums) == 3 {
-		interesting[prev.Pos] = nums
-	}
+	d := deductions{}
+	interesting := s.cellCandidates(2, 3)
 
 	poss := []Pos{}
 	for key := range interesting {
@@ -871,211 +933,250 @@ func (s *Sudoku) findXYZWings() finderResult {
 			n := common[0]
 
 			nfound := s.Candidates.Filter(func(c Cell) bool {
-				return c.Value == n && c.Pos.sees(pivot) && c.Pos.sees(w1) && c.Pos.sees(w2)
+				return c.Value == n && c.Pos != pivot && c.Pos != w1 && c.Pos != w2 &&
+					c.Pos.sees(pivot) && c.Pos.sees(w1) && c.Pos.sees(w2)
 			})
 
-			found = append(found, nfound...)
+			d.add(finderResult{Solved: nil, Eliminated: nfound, Pattern: s.Candidates.Filter(func(c Cell) bool {
+				return c.Pos == w1 || c.Pos == pivot || c.Pos == w2
+			})})
 		}
 	}
 
-	return finderResult{Solved: nil, Eliminated: found}
+	return d.result()
 }
 
 func (s *Sudoku) findXWings() finderResult {
-	type pair struct {
-		linef     func(int8) CellList
-		eqLine    func(Pos, Pos) bool
-		parallelf func(Pos) CellList
-	}
+	return s.findFish(2, false)
+}
 
-	var i, j int8
-	finder := func(fp pair) CellList {
-		found := CellList{}
+func PrintGrid(grid string) error {
+	return writeGridRows(os.Stdout, grid)
+}
 
-		for i = 1; i <= 8; i++ {
-			line1 := fp.linef(i)
+type strategy struct {
+	fun  func(*Sudoku) finderResult
+	name string
+	// Optional strategies are used only when asked for by name
+	optional bool
+}
 
-			if len(line1) <= 2 {
-				continue
-			}
+var strategies = []strategy{
+	{fun: (*Sudoku).findSinglesSimple, name: "singles (simple)"},
+	{fun: (*Sudoku).findSingles, name: "singles"},
+	{fun: (*Sudoku).findNakedPairs, name: "naked pairs"},
+	{fun: (*Sudoku).findNakedTriples, name: "naked triples"},
+	{fun: (*Sudoku).findHiddenPairs, name: "hidden pairs"},
+	{fun: (*Sudoku).findHiddenTriples, name: "hidden triples"},
+	{fun: (*Sudoku).findNakedQuads, name: "naked quads"},
+	{fun: (*Sudoku).findHiddenQuads, name: "hidden quads"},
+	{fun: (*Sudoku).findPointingPairs, name: "pointing pairs"},
+	{fun: (*Sudoku).findBoxlineReduction, name: "box/line reduction"},
+	{fun: (*Sudoku).findXWings, name: "x-wing"},
+	{fun: (*Sudoku).findYWings, name: "y-wing"},
+	{fun: (*Sudoku).findXYZWings, name: "xyz-wing"},
+	{fun: (*Sudoku).findSwordfish, name: "swordfish"},
+	{fun: (*Sudoku).findJellyfish, name: "jellyfish"},
+	{fun: (*Sudoku).findWWings, name: "w-wing"},
+	{fun: (*Sudoku).findSkyscrapers, name: "skyscraper"},
+	{fun: (*Sudoku).findTwoStringKites, name: "2-string kite"},
+	{fun: (*Sudoku).findTurbotFish, name: "turbot fish"},
+	{fun: (*Sudoku).findEmptyRectangles, name: "empty rectangle"},
+	{fun: (*Sudoku).findWXYZWings, name: "wxyz-wing", optional: true},
+	{fun: (*Sudoku).findFinnedXWings, name: "finned x-wing", optional: true},
+	{fun: (*Sudoku).findFinnedSwordfish, name: "finned swordfish", optional: true},
+	{fun: (*Sudoku).findFinnedJellyfish, name: "finned jellyfish", optional: true},
+	{fun: (*Sudoku).findTemplates, name: "template", optional: true},
+	{fun: (*Sudoku).findNishio, name: "nishio", optional: true},
+	{fun: (*Sudoku).findSueDeCoqs, name: "sue de coq", optional: true},
+	{fun: (*Sudoku).findALSXZ, name: "als-xz", optional: true},
+	{fun: (*Sudoku).findALSXYWings, name: "als-xy-wing", optional: true},
+	{fun: (*Sudoku).findDeathBlossoms, name: "death blossom", optional: true},
+	{fun: (*Sudoku).findBUG1, name: "bug+1", optional: true},
+	{fun: (*Sudoku).findBUGN, name: "bug+n", optional: true},
+	{fun: (*Sudoku).findAvoidableRectangles, name: "avoidable rectangle", optional: true},
+	{fun: (*Sudoku).findExtendedUniqueRectangles, name: "extended unique rectangle", optional: true},
+	{fun: (*Sudoku).findAlignedPairExclusions, name: "aligned pair exclusion", optional: true},
+	{fun: (*Sudoku).findAlignedTripleExclusions, name: "aligned triple exclusion", optional: true},
+	{fun: (*Sudoku).find3DMedusa, name: "3d medusa", optional: true},
+	{fun: (*Sudoku).findJuniorExocets, name: "junior exocet", optional: true},
+	{fun: (*Sudoku).findCellForcingChains, name: "cell forcing chain", optional: true},
+	{fun: (*Sudoku).findRegionForcingChains, name: "region forcing chain", optional: true},
+	{fun: (*Sudoku).findDigitForcingChains, name: "digit forcing chain", optional: true},
+}
 
-			line1Numbers := numbers(line1)
-			twos1 := numberCounts(line1Numbers).Filter(
-				func(nc numCount) bool {
-					return nc.count == 2
-				})
+func defaultStrategies() []strategy {
+	res := []strategy{}
 
-			if len(twos1) < 1 {
-				continue
-			}
+	for _, st := range strategies {
+		if !st.optional {
+			res = append(res, st)
+		}
+	}
 
-			for j = i + 1; j <= sudokuNumbers; j++ {
-				line2 := fp.linef(j)
-				if len(line2) <= 2 {
-					continue
-				}
+	return res
+}
 
-				line2Numbers := numbers(line2)
-				twos2 := numberCounts(line2Numbers).Filter(
-					func(nc numCount) bool {
-						return nc.count == 2
-					})
+func findStrategy(name string) (strategy, bool) {
+	for _, st := range strategies {
+		if st.name == name {
+			return st, true
+		}
+	}
 
-				if len(twos2) < 1 {
-					continue
-				}
+	return strategy{}, false
+}
 
-				for _, nc := range twos1 {
-					res := twos2.Filter(func(other numCount) bool {
-						return nc.num == other.num
-					})
-
-					if len(res) != 1 {
-						continue
-					}
-
-					// fmt.Printf("Check lines %d -- %d\n", i, j)
-					// fmt.Println(nc, res[0])
-
-					cells1 := line1.Filter(func(c Cell) bool {
-						return nc.num == c.Value
-					})
-
-					cells2 := line2.Filter(func(c Cell) bool {
-						return nc.num == c.Value
-					})
-
-					// fmt.Println(cells1, cells2)
-
-					if fp.eqLine(cells1[0].Pos, cells2[0].Pos) &&
-						fp.eqLine(cells1[1].Pos, cells2[1].Pos) {
-						found1 := fp.parallelf(cells1[0].Pos).Filter(func(c Cell) bool {
-							return nc.num == c.Value &&
-								cells1[0].Pos != c.Pos &&
-								cells2[0].Pos != c.Pos
-						})
-
-						found2 := fp.parallelf(cells1[1].Pos).Filter(func(c Cell) bool {
-							return nc.num == c.Value &&
-								cells1[1].Pos != c.Pos &&
-								cells2[1].Pos != c.Pos
-						})
-
-						if len(found1) > 0 {
-							found = append(found, found1...)
-						}
-
-						if len(found2) > 0 {
-							found = append(found, found2...)
-						}
-					}
-				}
-			}
-		}
+// apply updates the grid with the result of a finder, returns true if
+// progress was made.
+func (s *Sudoku) apply(name string, res finderResult) bool {
+	before := s.Candidates
 
-		return found
+	if len(res.Solved) > 0 {
+		s.logSolved(name, res.Solved...)
+		s.updateSolved(res.Solved)
 	}
 
-	fpairs := []pair{
-		{s.getCandidateRow, Pos.eqColumn,
-			func(p Pos) CellList {
-				return s.getCandidateColumn(p.Column)
-			},
-		},
-		{s.getCandidateColumn, Pos.eqRow,
-			func(p Pos) CellList {
-				return s.getCandidateRow(p.Row)
-			},
-		},
+	if len(res.Eliminated) > 0 {
+		s.logEliminated(name, res.Eliminated...)
+		s.updateCandidates(res.Eliminated)
 	}
 
-	found := CellList{}
+	if len(res.Solved) == 0 && len(res.Eliminated) == 0 {
+		return false
+	}
 
-	for _, fp := range fpairs {
-		cells := finder(fp)
-		found = append(found, cells...)
+	if s.trace != nil {
+		s.trace.record(name, res, before, s.Candidates)
 	}
 
-	return finderResult{Solved: nil, Eliminated: found}
+	return true
 }
 
-func PrintGrid(grid string) error {
-	if len(grid) != sudokuGridSize {
-		return fmt.Errorf("Grid has invalid size '%d'", len(grid))
-	}
+func (s *Sudoku) runStrategy(st strategy) bool {
+	start := time.Now()
+	res := st.fun(s)
+	elapsed := time.Since(start)
 
-	for i, c := range grid {
-		fmt.Printf("%c", c)
-		if (i + 1) % sudokuNumbers == 0 {
-			fmt.Print("\n")
-		} else {
-			fmt.Print(" ")
-		}
+	progress := s.apply(st.name, res)
+
+	if s.stats != nil {
+		s.stats.record(st.name, res, progress, elapsed)
 	}
 
-	return nil
+	return progress
 }
 
-func (s *Sudoku) Solve() bool {
-	type finderFunc struct {
-		fun  func() finderResult
-		name string
+// A single deduction considered by runSimplest
+type stepChoice struct {
+	finder     int
+	res        finderResult
+	step       Step
+	difficulty float64
+	// Number of candidates removed
+	effect int
+}
+
+// simpler orders deductions by difficulty, effect, strategy and at last
+// by the cells, so that the order the finders list them does not matter.
+func (sc *stepChoice) simpler(other *stepChoice) bool {
+	if sc.difficulty != other.difficulty {
+		return sc.difficulty < other.difficulty
 	}
 
-	finders := []finderFunc{
-		{fun: s.findSinglesSimple, name: "singles (simple)"},
-		{fun: s.findSingles, name: "singles"},
-		{fun: s.findNakedPairs, name: "naked pairs"},
-		{fun: s.findNakedTriples, name: "naked triples"},
-		{fun: s.findHiddenPairs, name: "hidden pairs"},
-		{fun: s.findHiddenTriples, name: "hidden triples"},
-		{fun: s.findNakedQuads, name: "naked quads"},
-		{fun: s.findHiddenQuads, name: "hidden quads"},
-		{fun: s.findPointingPairs, name: "pointing pairs"},
-		{fun: s.findBoxlineReduction, name: "box/line reduction"},
-		{fun: s.findXWings, name: "x-wing"},
-		{fun: s.findYWings, name: "y-wing"},
-		{fun: s.findXYZWings, name: "xyz-wing"},
+	if sc.effect != other.effect {
+		return sc.effect > other.effect
 	}
 
-	// fmt.Println("begin", len(s.Candidates))
-	finderCount := len(finders)
-	finderIdx := 0
+	if di, oi := strategyIndex(sc.step.Strategy), strategyIndex(other.step.Strategy); di != oi {
+		return di < oi
+	}
 
-PROGRESS:
-	for finderIdx < finderCount {
-		if len(s.Candidates) == 0 {
-			return true
+	for _, lists := range [][2]CellList{
+		{sc.step.Solved, other.step.Solved},
+		{sc.step.Eliminated, other.step.Eliminated},
+		{sc.step.Pattern, other.step.Pattern},
+	} {
+		if c := compareCells(lists[0], lists[1]); c != 0 {
+			return c < 0
 		}
+	}
 
-		// fmt.Println("Finder", finderIdx)
-
-		finder := finders[finderIdx]
-		res := finder.fun()
+	return false
+}
 
-		if len(res.Solved) > 0 {
-			s.logSolved(finder.name, res.Solved...)
-			s.updateSolved(res.Solved)
+// compareCells compares sorted cell lists lexicographically.
+func compareCells(a, b CellList) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i].less(&b[i]) {
+			return -1
+		}
+		if b[i].less(&a[i]) {
+			return 1
 		}
-		s.validate()
+	}
+
+	return len(a) - len(b)
+}
+
+// runSimplest runs all the strategies and applies the simplest of the
+// single deductions they found, see SolveOptions.Simplest.
+func (s *Sudoku) runSimplest(finders []strategy) bool {
+	results := make([]finderResult, len(finders))
+	elapsed := make([]time.Duration, len(finders))
+
+	var best *stepChoice
+
+	for i, st := range finders {
+		start := time.Now()
+		res := st.fun(s)
+		results[i], elapsed[i] = res, time.Since(start)
 
-		if len(res.Eliminated) > 0 {
-			s.logEliminated(finder.name, res.Eliminated...)
-			s.updateCandidates(res.Eliminated)
+		parts := res.parts
+		if parts == nil {
+			parts = []finderResult{res}
 		}
 
-		if len(s.Candidates) == 0 {
-			return true
+		for _, part := range parts {
+			if len(part.Solved) == 0 && len(part.Eliminated) == 0 {
+				continue
+			}
+
+			c := s.clone()
+			c.apply(st.name, part)
+
+			step := newStep(0, st.name, part, s.Candidates, c.Candidates)
+			sc := stepChoice{finder: i, res: part, step: step,
+				difficulty: step.SEDifficulty(), effect: len(s.Candidates) - len(c.Candidates)}
+
+			if best == nil || sc.simpler(best) {
+				best = &sc
+			}
 		}
+	}
+
+	progress := false
+	if best != nil {
+		progress = s.apply(finders[best.finder].name, best.res)
+	}
 
-		if len(res.Solved) != 0 || len(res.Eliminated) != 0 {
-			// fmt.Println("progress", len(s.Candidates))
-			finderIdx = 0
-			continue PROGRESS
+	if s.stats != nil {
+		for i, st := range finders {
+			res := results[i]
+			if best != nil && i == best.finder {
+				res = best.res
+			}
+			s.stats.record(st.name, res, progress && best != nil && i == best.finder, elapsed[i])
 		}
-		finderIdx++
 	}
-	return false
+
+	return progress
+}
+
+func (s *Sudoku) Solve() bool {
+	res, _ := s.SolveContext(context.Background(), SolveOptions{})
+
+	return res.Status == StatusSolved
 }
 
 func dedupePos(poss []Pos) []Pos {
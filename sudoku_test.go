@@ -1,6 +1,8 @@
 package sudoku_test
 
 import (
+	"context"
+
 	"github.com/jjhoo/go-sudoku"
 	"gotest.tools/v3/assert"
 
@@ -44,7 +46,7 @@ func TestGrid3(t *testing.T) {
 
 func TestGrid4(t *testing.T) {
 	grid := "000921003009000060000000500080403006007000800500700040003000000020000700800195000"
-	unsolvableSudoku(t, grid)
+	solvableSudoku(t, grid)
 }
 
 func TestGrid5(t *testing.T) {
@@ -54,7 +56,21 @@ func TestGrid5(t *testing.T) {
 
 func TestGrid6(t *testing.T) {
 	grid := "000704005020010070000080002090006250600070008053200010400090000030060090200407000"
-	solvableSudoku(t, grid)
+
+	// The default strategies got through only with xyz-wing eliminating
+	// from its own pivot, see TestXYZWingPivot. ALS-XZ is needed instead.
+	s, err := sudoku.NewSudoku(grid)
+	assert.NilError(t, err)
+
+	res, err := s.SolveContext(context.Background(), sudoku.SolveOptions{
+		Strategies: append(sudoku.Strategies(), "als-xz"),
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, sudoku.StatusSolved, res.Status)
+
+	solution, ok := s.Solution()
+	assert.Assert(t, ok)
+	assert.Equal(t, solution, s.GetGridString())
 }
 
 func TestBadInput1(t *testing.T) {
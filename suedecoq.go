@@ -0,0 +1,156 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"math/bits"
+)
+
+// subsets returns the non-empty subsets of poss with at most max cells.
+func subsets(poss []Pos, max int) [][]Pos {
+	res := [][]Pos{}
+
+	for size := 1; size <= max && size <= len(poss); size++ {
+		combs := newCombination(len(poss), size)
+		for {
+			var idxs intList = combs.next()
+			if idxs == nil {
+				break
+			}
+
+			res = append(res, idxs.MapPos(func(n int) Pos { return poss[n] }))
+		}
+	}
+
+	return res
+}
+
+func unionMask(masks *[sudokuGridSize]uint16, poss []Pos) uint16 {
+	var mask uint16
+
+	for _, pos := range poss {
+		mask |= masks[pos.index()]
+	}
+
+	return mask
+}
+
+// Sue de Coq: two or three cells in the intersection of a box and a line
+// with at least two candidates more than cells, and cells of the rest of
+// the line and the rest of the box, with no common candidates between
+// the line and box parts. If all of them together have as many
+// candidates as cells, they are locked: the numbers of the line part are
+// eliminated from the rest of the line, the numbers of the box part from
+// the rest of the box, and the other numbers from both.
+func (s *Sudoku) findSueDeCoqs() finderResult {
+	d := deductions{}
+
+	masks := candidateMasks(s.Candidates)
+
+	lines := []struct {
+		getter cellGetter
+		index  func(Pos) int8
+	}{
+		{s.getCandidateRow, func(p Pos) int8 { return p.Row }},
+		{s.getCandidateColumn, func(p Pos) int8 { return p.Column }},
+	}
+
+	for box := int8(1); box <= sudokuNumbers; box++ {
+		boxPoss := ucpos(s.getCandidateBox(box))
+
+		for _, line := range lines {
+			seen := map[int8]bool{}
+
+			for _, bpos := range boxPoss {
+				li := line.index(bpos)
+				if seen[li] {
+					continue
+				}
+				seen[li] = true
+
+				linePoss := ucpos(line.getter(li))
+
+				inter := []Pos{}
+				boxRest := []Pos{}
+				for _, pos := range boxPoss {
+					if line.index(pos) == li {
+						inter = append(inter, pos)
+					} else {
+						boxRest = append(boxRest, pos)
+					}
+				}
+
+				lineRest := []Pos{}
+				for _, pos := range linePoss {
+					if pos.Box != box {
+						lineRest = append(lineRest, pos)
+					}
+				}
+
+				if len(inter) < 2 || len(lineRest) == 0 || len(boxRest) == 0 {
+					continue
+				}
+
+				for _, cs := range subsets(inter, len(inter)) {
+					v := unionMask(&masks, cs)
+					if len(cs) < 2 || bits.OnesCount16(v) < len(cs)+2 {
+						continue
+					}
+
+					for _, as := range subsets(lineRest, len(lineRest)) {
+						va := unionMask(&masks, as)
+
+						for _, bs := range subsets(boxRest, len(boxRest)) {
+							vb := unionMask(&masks, bs)
+
+							all := v | va | vb
+							if va&vb != 0 || bits.OnesCount16(all) != len(cs)+len(as)+len(bs) {
+								continue
+							}
+
+							lineNums := (v | va) &^ vb
+							boxNums := (v | vb) &^ va
+
+							used := map[Pos]bool{}
+							for _, group := range [][]Pos{cs, as, bs} {
+								for _, pos := range group {
+									used[pos] = true
+								}
+							}
+
+							nfound := s.Candidates.Filter(func(c Cell) bool {
+								if used[c.Pos] {
+									return false
+								}
+
+								bit := uint16(1) << uint(c.Value)
+								return (line.index(c.Pos) == li && lineNums&bit != 0) ||
+									(c.Pos.Box == box && boxNums&bit != 0)
+							})
+
+							d.add(finderResult{Solved: nil, Eliminated: nfound, Pattern: s.Candidates.Filter(func(c Cell) bool {
+								return used[c.Pos]
+							})})
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return d.result()
+}
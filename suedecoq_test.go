@@ -0,0 +1,61 @@
+package sudoku_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+func TestSueDeCoq(t *testing.T) {
+	names := baseStrategies
+
+	tests := []struct {
+		grid       string
+		eliminated sudoku.CellList
+		pattern    sudoku.CellList
+	}{
+		// r2c8 r3c8 (1248) in box 3 and column 8, r9c8 (12) in the column
+		// and r3c7 (48) in the box
+		{"000000060100000709030672000370025100001300050900001000000060003000000000004807906",
+			sudoku.CellList{cell(1, 9, 3, 4), cell(3, 9, 3, 4), cell(7, 8, 9, 1), cell(7, 8, 9, 2)},
+			sudoku.CellList{cell(2, 8, 3, 2), cell(2, 8, 3, 8), cell(3, 7, 3, 4), cell(3, 7, 3, 8),
+				cell(3, 8, 3, 1), cell(3, 8, 3, 4), cell(3, 8, 3, 8), cell(9, 8, 9, 1), cell(9, 8, 9, 2)}},
+		// r1c7 r2c7 r3c7 (124689) in box 3 and column 7, r5c7 (68) and
+		// r9c7 (48) in the column and r3c8 r3c9 (279) in the box
+		{"000620053000000000460800000070100000900000014200000700000040560008002300092500000",
+			sudoku.CellList{cell(2, 8, 3, 7), cell(2, 9, 3, 7), cell(2, 9, 3, 9), cell(4, 7, 6, 6)},
+			sudoku.CellList{cell(1, 7, 3, 1), cell(1, 7, 3, 4), cell(1, 7, 3, 8), cell(1, 7, 3, 9),
+				cell(2, 7, 3, 1), cell(2, 7, 3, 4), cell(2, 7, 3, 6), cell(2, 7, 3, 8), cell(2, 7, 3, 9),
+				cell(3, 7, 3, 1), cell(3, 7, 3, 2), cell(3, 7, 3, 9), cell(3, 8, 3, 2), cell(3, 8, 3, 7),
+				cell(3, 9, 3, 7), cell(3, 9, 3, 9), cell(5, 7, 6, 6), cell(5, 7, 6, 8),
+				cell(9, 7, 9, 4), cell(9, 7, 9, 8)}},
+	}
+
+	for _, test := range tests {
+		s, err := sudoku.NewSudoku(test.grid)
+		assert.NilError(t, err)
+
+		res, err := s.SolveContext(context.Background(), sudoku.SolveOptions{Strategies: names})
+		assert.NilError(t, err)
+		assert.Equal(t, sudoku.StatusStalled, res.Status)
+
+		step, ok, err := s.HintWith([]string{"sue de coq"})
+		assert.NilError(t, err)
+		assert.Assert(t, ok)
+		assert.DeepEqual(t, test.eliminated, step.Eliminated)
+		assert.DeepEqual(t, test.pattern, step.Pattern)
+
+		assert.NilError(t, s.ApplyStep(step))
+
+		// Sue de coq is the bottleneck, the rest is solved without it
+		res, err = s.SolveContext(context.Background(), sudoku.SolveOptions{Strategies: names})
+		assert.NilError(t, err)
+		assert.Equal(t, sudoku.StatusSolved, res.Status)
+
+		solution, ok := s.Solution()
+		assert.Assert(t, ok)
+		assert.Equal(t, solution, s.GetGridString())
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"fmt"
+)
+
+// Cells of each row, column and box, indexed like houses
+var houseSets = func() [3 * sudokuNumbers]cellSet {
+	var res [3 * sudokuNumbers]cellSet
+
+	for i := 0; i < sudokuGridSize; i++ {
+		for _, h := range houses(indexPos(i)) {
+			res[h].add(i)
+		}
+	}
+
+	return res
+}()
+
+// A template is a placement of a number in every row, column and box
+var templates = func() []cellSet {
+	res := []cellSet{}
+
+	var visit func(row int, columns, boxes uint16, cells cellSet)
+	visit = func(row int, columns, boxes uint16, cells cellSet) {
+		if row == sudokuNumbers {
+			res = append(res, cells)
+			return
+		}
+
+		for col := 0; col < sudokuNumbers; col++ {
+			box := row/3*3 + col/3
+			if columns&(1<<uint(col)) != 0 || boxes&(1<<uint(box)) != 0 {
+				continue
+			}
+
+			next := cells
+			next.add(row*sudokuNumbers + col)
+			visit(row+1, columns|1<<uint(col), boxes|1<<uint(box), next)
+		}
+	}
+	visit(0, 0, 0, cellSet{})
+
+	return res
+}()
+
+func houseName(h int) string {
+	return fmt.Sprintf("%s %d", []string{"row", "column", "box"}[h/sudokuNumbers], h%sudokuNumbers+1)
+}
+
+// digitCells returns the cells where the number is solved and where it is
+// a candidate.
+func (s *Sudoku) digitCells(n int8) (solved, cands cellSet) {
+	for i, c := range s.Solved {
+		if c.Value == n {
+			solved.add(i)
+		}
+	}
+
+	for _, c := range s.Candidates {
+		if c.Value == n {
+			cands.add(c.Pos.index())
+		}
+	}
+
+	return solved, cands
+}
+
+func (s *Sudoku) digitCandidates(n int8, cells cellSet) CellList {
+	return s.Candidates.Filter(func(c Cell) bool {
+		return c.Value == n && cells.has(c.Pos.index())
+	})
+}
+
+// findTemplates overlays the templates of each number that fit the grid.
+// Candidates in none of them are eliminated, ones in all of them are
+// placed.
+func (s *Sudoku) findTemplates() finderResult {
+	for n := int8(1); n <= sudokuNumbers; n++ {
+		solved, cands := s.digitCells(n)
+		if cands.empty() {
+			continue
+		}
+
+		allowed := solved.or(cands)
+		union := cellSet{}
+		common := cellSet{^uint64(0), ^uint64(0)}
+		fits := 0
+
+		for _, t := range templates {
+			if t.subsetOf(allowed) && solved.subsetOf(t) {
+				union = union.or(t)
+				common = common.and(t)
+				fits++
+			}
+		}
+
+		if fits == 0 {
+			continue
+		}
+
+		found := s.digitCandidates(n, cands.andNot(union))
+		placed := s.digitCandidates(n, cands.and(common))
+
+		if len(found) > 0 || len(placed) > 0 {
+			return finderResult{Solved: placed, Eliminated: found, Pattern: s.digitCandidates(n, cands)}
+		}
+	}
+
+	return finderResult{Solved: nil, Eliminated: nil}
+}
+
+// nishio assumes the candidate is the number and places it where it is
+// the only one left in a house, until the number runs out of places in
+// some house or it is placed everywhere.
+func (s *Sudoku) nishio(c Cell, solved, cands cellSet) (ForcingBranch, bool) {
+	branch := ForcingBranch{Assumption: c}
+
+	place := func(idx int) {
+		solved.add(idx)
+		cands = cands.andNot(peerSets[idx]).andNot(singleCell(idx))
+	}
+	place(c.Pos.index())
+
+	for {
+		progress := false
+
+		for h, house := range houseSets {
+			if !house.and(solved).empty() {
+				continue
+			}
+
+			left := house.and(cands).indexes()
+
+			switch len(left) {
+			case 0:
+				branch.Contradiction = fmt.Sprintf("No place left for %d in %s", c.Value, houseName(h))
+				return branch, true
+			case 1:
+				place(left[0])
+				branch.Links = append(branch.Links, ForcingLink{
+					Strategy: "singles",
+					Solved:   CellList{{Pos: indexPos(left[0]), Value: c.Value}},
+				})
+				progress = true
+			}
+		}
+
+		if !progress {
+			return branch, false
+		}
+	}
+}
+
+func singleCell(idx int) cellSet {
+	var cs cellSet
+	cs.add(idx)
+	return cs
+}
+
+// findNishio eliminates candidates of a number that would leave the
+// number without a place in some house, the contradictions are reported
+// in Chain.
+func (s *Sudoku) findNishio() finderResult {
+	for n := int8(1); n <= sudokuNumbers; n++ {
+		solved, cands := s.digitCells(n)
+		res := finderResult{Solved: nil, Eliminated: CellList{}, Pattern: s.digitCandidates(n, cands)}
+
+		for _, c := range res.Pattern {
+			if branch, ok := s.nishio(c, solved, cands); ok {
+				res.Eliminated = append(res.Eliminated, c)
+				res.Chain = append(res.Chain, branch)
+			}
+		}
+
+		if len(res.Eliminated) > 0 {
+			return res
+		}
+	}
+
+	return finderResult{Solved: nil, Eliminated: nil}
+}
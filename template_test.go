@@ -0,0 +1,66 @@
+package sudoku_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+const templateGrid = "004001090020000000507009802002000700703040000000800400000000280200003005039000001"
+
+func TestTemplate(t *testing.T) {
+	s := forcingState(t, templateGrid)
+
+	solution, ok := s.Solution()
+	assert.Assert(t, ok)
+
+	step, ok, err := s.HintWith([]string{"template"})
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+
+	// Every template of 6 fitting the grid has r7c1
+	assert.DeepEqual(t, sudoku.CellList{cell(7, 1, 7, 6)}, step.Solved)
+	assert.DeepEqual(t, sudoku.CellList{cell(6, 1, 4, 6), cell(7, 4, 8, 6), cell(8, 3, 7, 6)}, step.Eliminated)
+
+	assert.NilError(t, s.ApplyStep(step))
+	assertSound(t, s, solution)
+}
+
+func TestNishio(t *testing.T) {
+	s := forcingState(t, templateGrid)
+
+	step, ok, err := s.HintWith([]string{"nishio"})
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+
+	// Same eliminations as with the templates, each with its own
+	// contradiction
+	assert.DeepEqual(t, sudoku.CellList{cell(6, 1, 4, 6), cell(7, 4, 8, 6), cell(8, 3, 7, 6)}, step.Eliminated)
+	assert.Equal(t, len(step.Eliminated), len(step.Chain))
+
+	branch := step.Chain[0]
+	assert.Equal(t, cell(6, 1, 4, 6), branch.Assumption)
+	assert.DeepEqual(t, []sudoku.ForcingLink{
+		{Strategy: "singles", Solved: sudoku.CellList{cell(5, 9, 6, 6)}},
+		{Strategy: "singles", Solved: sudoku.CellList{cell(7, 4, 8, 6)}},
+		{Strategy: "singles", Solved: sudoku.CellList{cell(8, 3, 7, 6)}},
+		{Strategy: "singles", Solved: sudoku.CellList{cell(1, 2, 1, 6)}},
+	}, branch.Links)
+	assert.Equal(t, "No place left for 6 in column 5", branch.Contradiction)
+}
+
+func TestTemplateGrid4(t *testing.T) {
+	// The templates subsume the single digit patterns solving the grid
+	for _, name := range []string{"template", "nishio"} {
+		s, err := sudoku.NewSudoku("000921003009000060000000500080403006007000800500700040003000000020000700800195000")
+		assert.NilError(t, err)
+
+		res, err := s.SolveContext(context.Background(), sudoku.SolveOptions{
+			Strategies: append(copyNames(baseStrategies), name),
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, sudoku.StatusSolved, res.Status, name)
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Step is a single deduction made by a strategy while solving.
+type Step struct {
+	Number   int    `json:"step"`
+	Strategy string `json:"strategy"`
+	// Candidates forming the pattern the deduction is based on
+	Pattern    CellList `json:"pattern,omitempty"`
+	Solved     CellList `json:"solved,omitempty"`
+	Eliminated CellList `json:"eliminated,omitempty"`
+	// Candidates of a coloring pattern by color
+	Colors []CellList `json:"colors,omitempty"`
+	// Assumptions of a forcing chain and the steps following from them
+	Chain []ForcingBranch `json:"chain,omitempty"`
+	// Candidates of the cells changed by the step, before and after it
+	Before CellList `json:"before"`
+	After  CellList `json:"after"`
+}
+
+// Trace records the steps taken by Solve.
+type Trace struct {
+	Grid  string `json:"grid"`
+	Steps []Step `json:"steps,omitempty"`
+}
+
+// SetTrace attaches a trace recorder to the sudoku, nil detaches the
+// current one. The starting grid is recorded if the trace has none yet.
+func (s *Sudoku) SetTrace(t *Trace) {
+	if t != nil && t.Grid == "" {
+		t.Grid = s.GetGridString()
+	}
+	s.trace = t
+}
+
+func changedPositions(before, after CellList) [sudokuGridSize]bool {
+	var changed [sudokuGridSize]bool
+
+	bmasks := candidateMasks(before)
+	amasks := candidateMasks(after)
+
+	for i := range bmasks {
+		changed[i] = bmasks[i] != amasks[i]
+	}
+
+	return changed
+}
+
+func candidatesAt(cells CellList, poss [sudokuGridSize]bool) CellList {
+	return cells.Filter(func(c Cell) bool {
+		return poss[c.Pos.index()]
+	})
+}
+
+func normalizeCells(cells CellList) CellList {
+	if len(cells) == 0 {
+		return nil
+	}
+
+	return uniqueCells(copyCells(cells))
+}
+
+func normalizeColors(colors []CellList) []CellList {
+	if len(colors) == 0 {
+		return nil
+	}
+
+	res := make([]CellList, len(colors))
+	for i, cells := range colors {
+		res[i] = normalizeCells(cells)
+	}
+
+	return res
+}
+
+func newStep(number int, strategy string, res finderResult, before, after CellList) Step {
+	changed := changedPositions(before, after)
+
+	return Step{
+		Number:     number,
+		Strategy:   strategy,
+		Pattern:    normalizeCells(res.Pattern),
+		Colors:     normalizeColors(res.Colors),
+		Chain:      res.Chain,
+		Solved:     normalizeCells(res.Solved),
+		Eliminated: normalizeCells(res.Eliminated),
+		Before:     candidatesAt(before, changed),
+		After:      candidatesAt(after, changed),
+	}
+}
+
+func (t *Trace) record(strategy string, res finderResult, before, after CellList) {
+	t.Steps = append(t.Steps, newStep(len(t.Steps)+1, strategy, res, before, after))
+}
+
+// WriteJSON writes the trace as a single JSON document.
+func (t *Trace) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(t)
+}
+
+// WriteNDJSON writes the trace as newline delimited JSON: a header line
+// with the grid followed by one line per step.
+func (t *Trace) WriteNDJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+
+	if err := enc.Encode(Trace{Grid: t.Grid}); err != nil {
+		return err
+	}
+
+	for _, step := range t.Steps {
+		if err := enc.Encode(step); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadTrace reads a trace written by either WriteJSON or WriteNDJSON.
+func ReadTrace(r io.Reader) (*Trace, error) {
+	dec := json.NewDecoder(r)
+
+	var t Trace
+	if err := dec.Decode(&t); err != nil {
+		return nil, err
+	}
+
+	for dec.More() {
+		var step Step
+		if err := dec.Decode(&step); err != nil {
+			return nil, err
+		}
+		t.Steps = append(t.Steps, step)
+	}
+
+	return &t, nil
+}
+
+func containsCells(cells, sub CellList) bool {
+	masks := candidateMasks(cells)
+
+	for _, c := range sub {
+		if masks[c.Pos.index()]&(1<<uint(c.Value)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// reproduces tells if the result of a finder covers the deductions of the
+// step. Steps taken with SolveOptions.Simplest are single deductions, one
+// of the parts of the result.
+func reproduces(res finderResult, step Step) bool {
+	if len(step.Solved) == 0 && len(step.Eliminated) == 0 {
+		return false
+	}
+
+	return containsCells(res.Solved, step.Solved) &&
+		containsCells(res.Eliminated, step.Eliminated)
+}
+
+// Replay applies the steps of the trace to a fresh sudoku. Every step is
+// verified to be found by its strategy, as a whole or as a part of its
+// result, and to have the recorded effect on the candidates.
+func Replay(t *Trace) (*Sudoku, error) {
+	s, err := NewSudoku(t.Grid)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, step := range t.Steps {
+		if step.Number != i+1 {
+			return s, fmt.Errorf("Step %d has unexpected number %d", i+1, step.Number)
+		}
+
+		st, ok := findStrategy(step.Strategy)
+		if !ok {
+			return s, fmt.Errorf("Step %d has unknown strategy '%s'", step.Number, step.Strategy)
+		}
+
+		if len(s.Candidates) == 0 {
+			return s, fmt.Errorf("Step %d follows a solved grid", step.Number)
+		}
+
+		var poss [sudokuGridSize]bool
+		for _, c := range step.Before {
+			poss[c.Pos.index()] = true
+		}
+
+		if !equalCells(candidatesAt(s.Candidates, poss), step.Before) {
+			return s, fmt.Errorf("Step %d: candidates before the step do not match", step.Number)
+		}
+
+		if !reproduces(st.fun(s), step) {
+			return s, fmt.Errorf("Step %d: %s does not reproduce the step", step.Number, step.Strategy)
+		}
+
+		before := s.Candidates
+		s.apply(step.Strategy, finderResult{Solved: step.Solved, Eliminated: step.Eliminated})
+
+		if err := s.validate(); err != nil {
+			return s, fmt.Errorf("Step %d: %v", step.Number, err)
+		}
+
+		if changedPositions(before, s.Candidates) != poss ||
+			!equalCells(candidatesAt(s.Candidates, poss), step.After) {
+			return s, fmt.Errorf("Step %d: candidates after the step do not match", step.Number)
+		}
+	}
+
+	return s, nil
+}
@@ -0,0 +1,106 @@
+package sudoku_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+func tracedSolve(t *testing.T, grid string) (*sudoku.Sudoku, *sudoku.Trace) {
+	s, err := sudoku.NewSudoku(grid)
+	assert.NilError(t, err)
+
+	trace := &sudoku.Trace{}
+	s.SetTrace(trace)
+	s.Solve()
+
+	return s, trace
+}
+
+func TestTraceRecord(t *testing.T) {
+	grid := "000040700500780020070002006810007900460000051009600078900800010080064009002050000"
+
+	_, trace := tracedSolve(t, grid)
+
+	assert.Equal(t, grid, trace.Grid)
+	assert.Assert(t, len(trace.Steps) > 0)
+
+	for i, step := range trace.Steps {
+		assert.Equal(t, i+1, step.Number)
+		assert.Assert(t, step.Strategy != "")
+		assert.Assert(t, len(step.Solved)+len(step.Eliminated) > 0)
+		assert.Assert(t, len(step.Before) > len(step.After))
+	}
+}
+
+func TestTraceReplay(t *testing.T) {
+	grids := []string{
+		"014600300050000007090840100000400800600050009007009000008016030300000010009008570",
+		"000921003009000060000000500080403006007000800500700040003000000020000700800195000",
+	}
+
+	for _, grid := range grids {
+		s, trace := tracedSolve(t, grid)
+
+		var buf bytes.Buffer
+		assert.NilError(t, trace.WriteJSON(&buf))
+
+		trace2, err := sudoku.ReadTrace(&buf)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, trace, trace2)
+
+		s2, err := sudoku.Replay(trace2)
+		assert.NilError(t, err)
+		assert.Equal(t, s.GetGridString(), s2.GetGridString())
+		assert.DeepEqual(t, s.Candidates, s2.Candidates)
+	}
+}
+
+func TestTraceNDJSON(t *testing.T) {
+	grid := "700600008800030000090000310006740005005806900400092100087000020000060009600008001"
+
+	_, trace := tracedSolve(t, grid)
+
+	var buf bytes.Buffer
+	assert.NilError(t, trace.WriteNDJSON(&buf))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, len(trace.Steps)+1, len(lines))
+
+	trace2, err := sudoku.ReadTrace(&buf)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, trace, trace2)
+}
+
+func TestTraceReplayMismatch(t *testing.T) {
+	grid := "000040700500780020070002006810007900460000051009600078900800010080064009002050000"
+
+	_, trace := tracedSolve(t, grid)
+
+	trace.Steps[0].Solved = trace.Steps[0].Solved[1:]
+
+	_, err := sudoku.Replay(trace)
+	assert.ErrorContains(t, err, "Step 1")
+}
+
+func TestTraceReplaySimplest(t *testing.T) {
+	grid := "000040700500780020070002006810007900460000051009600078900800010080064009002050000"
+
+	s, err := sudoku.NewSudoku(grid)
+	assert.NilError(t, err)
+
+	trace := &sudoku.Trace{}
+	s.SetTrace(trace)
+
+	res, err := s.SolveContext(context.Background(), sudoku.SolveOptions{Simplest: true})
+	assert.NilError(t, err)
+	assert.Equal(t, sudoku.StatusSolved, res.Status)
+
+	s2, err := sudoku.Replay(trace)
+	assert.NilError(t, err)
+	assert.Equal(t, s.GetGridString(), s2.GetGridString())
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2019-2026 Jani J. Hakala <jjhakala@gmail.com>, Finland
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, version 3 of the
+//  License.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sudoku
+
+import (
+	"math/bits"
+	"sort"
+)
+
+func sortedPositions(cands map[Pos][]int8) []Pos {
+	poss := []Pos{}
+	for key := range cands {
+		poss = append(poss, key)
+	}
+
+	sort.Slice(poss, func(i, j int) bool {
+		return poss[i].less(&poss[j])
+	})
+
+	return poss
+}
+
+// W-wing: two bivalue cells with the same numbers x and y, that do not see
+// each other, and a conjugate pair of x with one end seeing each cell. One
+// of the cells must be y, so y is eliminated from cells seeing both.
+func (s *Sudoku) findWWings() finderResult {
+	d := deductions{}
+
+	interesting := s.cellCandidates(2, 2)
+	poss := sortedPositions(interesting)
+	pairs := s.conjugatePairs()
+
+	combs := newCombination(len(poss), 2)
+	for {
+		var idxs intList = combs.next()
+		if idxs == nil {
+			break
+		}
+
+		p, q := poss[idxs[0]], poss[idxs[1]]
+		np, nq := interesting[p], interesting[q]
+
+		if np[0] != nq[0] || np[1] != nq[1] || p.sees(q) {
+			continue
+		}
+
+		for k, x := range np {
+			y := np[1-k]
+
+			for _, link := range pairs[x] {
+				ends := []Cell{link.a, link.b}
+
+				perms := newPermutation(len(ends))
+				for {
+					var idxs intList = perms.next()
+					if idxs == nil {
+						break
+					}
+
+					a, b := ends[idxs[0]], ends[idxs[1]]
+
+					if a.Pos == p || a.Pos == q || b.Pos == p || b.Pos == q {
+						continue
+					}
+
+					if !(a.Pos.sees(p) && b.Pos.sees(q)) {
+						continue
+					}
+
+					nfound := s.Candidates.Filter(func(c Cell) bool {
+						return c.Value == y && c.Pos != p && c.Pos != q &&
+							c.Pos.sees(p) && c.Pos.sees(q)
+					})
+
+					cells := s.Candidates.Filter(func(c Cell) bool {
+						return c.Pos == p || c.Pos == q
+					})
+					d.add(finderResult{Solved: nil, Eliminated: nfound, Pattern: append(cells, a, b)})
+				}
+			}
+		}
+	}
+
+	return d.result()
+}
+
+// WXYZ-wing: four cells with four numbers between them, where all but
+// one number z are restricted, that is the cells having the number see
+// each other. Some cell must be z, so z is eliminated from cells seeing
+// all cells of the wing with z.
+func (s *Sudoku) findWXYZWings() finderResult {
+	d := deductions{}
+
+	interesting := s.cellCandidates(2, 4)
+	poss := sortedPositions(interesting)
+
+	masks := make([]uint16, len(poss))
+	for i, pos := range poss {
+		for _, n := range interesting[pos] {
+			masks[i] |= 1 << uint(n)
+		}
+	}
+
+	combs := newCombination(len(poss), 4)
+	for {
+		var idxs intList = combs.next()
+		if idxs == nil {
+			break
+		}
+
+		var union uint16
+		for _, idx := range idxs {
+			union |= masks[idx]
+		}
+
+		if bits.OnesCount16(union) != 4 {
+			continue
+		}
+
+		var z int8
+		var zposs []Pos
+		unrestricted := 0
+
+		for n := int8(1); n <= sudokuNumbers; n++ {
+			if union&(1<<uint(n)) == 0 {
+				continue
+			}
+
+			nposs := []Pos{}
+			for _, idx := range idxs {
+				if masks[idx]&(1<<uint(n)) != 0 {
+					nposs = append(nposs, poss[idx])
+				}
+			}
+
+			restricted := true
+			for i := range nposs {
+				for j := i + 1; j < len(nposs); j++ {
+					if !nposs[i].sees(nposs[j]) {
+						restricted = false
+					}
+				}
+			}
+
+			if !restricted {
+				unrestricted++
+				z = n
+				zposs = nposs
+			}
+		}
+
+		// With all numbers restricted the cells would be a naked quad
+		if unrestricted != 1 {
+			continue
+		}
+
+		wing := PosList{}
+		for _, idx := range idxs {
+			wing = append(wing, poss[idx])
+		}
+
+		nfound := s.Candidates.Filter(func(c Cell) bool {
+			if c.Value != z || wing.Any(func(p Pos) bool { return p == c.Pos }) {
+				return false
+			}
+
+			for _, p := range zposs {
+				if !c.Pos.sees(p) {
+					return false
+				}
+			}
+			return true
+		})
+
+		d.add(finderResult{Solved: nil, Eliminated: nfound, Pattern: s.Candidates.Filter(func(c Cell) bool {
+			return wing.Any(func(p Pos) bool { return p == c.Pos })
+		})})
+	}
+
+	return d.result()
+}
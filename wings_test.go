@@ -0,0 +1,55 @@
+package sudoku_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jjhoo/go-sudoku"
+	"gotest.tools/v3/assert"
+)
+
+func TestWWing(t *testing.T) {
+	// r7c6 and r8c1 are 35, 5 in row 3 is in r3c1 or r3c6
+	grid := "000900000020400100006370000200501009051000030700200400100040600080000000009000305"
+
+	step := stalledHint(t, grid, "w-wing")
+	assert.DeepEqual(t, sudoku.CellList{cell(7, 2, 7, 3), cell(7, 3, 7, 3), cell(8, 5, 8, 3)}, step.Eliminated)
+	assert.DeepEqual(t, sudoku.CellList{cell(3, 1, 1, 5), cell(3, 6, 2, 5), cell(7, 6, 8, 3), cell(7, 6, 8, 5),
+		cell(8, 1, 7, 3), cell(8, 1, 7, 5)}, step.Pattern)
+}
+
+func TestWXYZWing(t *testing.T) {
+	// r3c1 (47), r4c6 (14), r6c1 (67) and r6c5 (16), 1, 6 and 7 are
+	// restricted, 4 is not
+	grid := "020000000100008040000620803080070300002500000030000004040090006309082000000050001"
+
+	step := stalledHint(t, grid, "wxyz-wing")
+	assert.DeepEqual(t, sudoku.CellList{cell(4, 1, 4, 4)}, step.Eliminated)
+	assert.DeepEqual(t, sudoku.CellList{cell(3, 1, 1, 4), cell(3, 1, 1, 7), cell(4, 6, 5, 1), cell(4, 6, 5, 4),
+		cell(6, 1, 4, 6), cell(6, 1, 4, 7), cell(6, 5, 5, 1), cell(6, 5, 5, 6)}, step.Pattern)
+}
+
+func TestXYZWingPivot(t *testing.T) {
+	grid := "030005000000096010000017040350240001060000290004000005600000000000000650008051009"
+
+	names := []string{}
+	for _, name := range baseStrategies {
+		if name == "xyz-wing" {
+			break
+		}
+		names = append(names, name)
+	}
+
+	s, err := sudoku.NewSudoku(grid)
+	assert.NilError(t, err)
+
+	res, err := s.SolveContext(context.Background(), sudoku.SolveOptions{Strategies: names})
+	assert.NilError(t, err)
+	assert.Equal(t, sudoku.StatusStalled, res.Status)
+
+	// r1c7 (789) with the wings r1c3 (79) and r4c7 (78): only the pivot
+	// itself sees all three cells, and its solution is 7
+	_, ok, err := s.HintWith([]string{"xyz-wing"})
+	assert.NilError(t, err)
+	assert.Assert(t, !ok)
+}